@@ -1,4 +0,0 @@
-package cloudsigma
-
-// providerVersion is set during the release process to the release version of the binary.
-var providerVersion = "dev"
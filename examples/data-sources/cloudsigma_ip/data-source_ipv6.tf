@@ -0,0 +1,3 @@
+data "cloudsigma_ip" "my_ipv6" {
+  ipv6 = true
+}
@@ -0,0 +1,3 @@
+data "cloudsigma_server" "web" {
+  name = "web"
+}
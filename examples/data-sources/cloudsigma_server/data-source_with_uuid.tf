@@ -0,0 +1,3 @@
+data "cloudsigma_server" "web" {
+  uuid = "2d3a2c8c-d8a8-4ec7-9d85-5e1c4c2ad7fb"
+}
@@ -0,0 +1,13 @@
+data "cloudsigma_servers" "web" {
+  name_prefix = "web-"
+  status      = "running"
+  tags        = ["<tag-uuid>"]
+}
+
+output "web_ipv4_addresses" {
+  value = data.cloudsigma_servers.web.servers[*].ipv4_address
+}
+
+output "web_all_ipv4_addresses" {
+  value = flatten(data.cloudsigma_servers.web.servers[*].ipv4_addresses)
+}
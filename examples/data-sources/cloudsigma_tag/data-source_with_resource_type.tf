@@ -0,0 +1,12 @@
+data "cloudsigma_tag" "nightly_backup" {
+  name          = "backup=nightly"
+  resource_type = "drives"
+}
+
+# snapshot all drives tagged for the nightly backup
+resource "cloudsigma_snapshot" "nightly" {
+  for_each = toset(data.cloudsigma_tag.nightly_backup.resources[*].uuid)
+
+  drive = each.value
+  name  = "nightly-${each.value}"
+}
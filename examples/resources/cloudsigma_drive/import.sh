@@ -0,0 +1,5 @@
+# Drive can be imported using the drive UUID.
+terraform import cloudsigma_drive.foobar 2ae05b0a-c538-47ac-ba41-e8519f782ab4
+
+# Drive can also be imported using the drive name, if the name is unique.
+terraform import cloudsigma_drive.foobar foobar
@@ -0,0 +1,8 @@
+resource "cloudsigma_drive" "foobar" {
+  media = "disk"
+  name  = "foobar"
+  size  = 5 * 1024 * 1024 * 1024 # 5GB
+
+  # tags which do not exist yet are created
+  tag_names = ["production", "database"]
+}
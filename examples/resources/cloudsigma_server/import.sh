@@ -0,0 +1,2 @@
+# Server can be imported using the server UUID.
+terraform import cloudsigma_server.web 2d3a2c8c-d8a8-4ec7-9d85-5e1c4c2ad7fb
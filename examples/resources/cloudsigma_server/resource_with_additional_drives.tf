@@ -10,7 +10,7 @@ resource "cloudsigma_server" "web" {
   name         = "web"
   vnc_password = "5$zFH9$w"
 
-  drive {
+  drive = [{
     uuid = cloudsigma_drive.data.id
-  }
+  }]
 }
@@ -0,0 +1,18 @@
+data "cloudsigma_library_drive" "debian" {
+  filter {
+    name   = "name"
+    values = ["Debian 9.13 Server"]
+  }
+}
+
+resource "cloudsigma_server" "web" {
+  cpu          = 2000              # 2GHz CPU
+  memory       = 512 * 1024 * 1024 # 512MB RAM
+  name         = "web"
+  vnc_password = "cloudsigma"
+
+  boot_disk = {
+    source_library_drive = data.cloudsigma_library_drive.debian.id
+    size                 = 10 * 1024 * 1024 * 1024 # 10GB
+  }
+}
@@ -0,0 +1,15 @@
+resource "cloudsigma_server" "windows" {
+  cpu          = 8000               # 8GHz CPU
+  memory       = 8192 * 1024 * 1024 # 8GB RAM
+  name         = "windows"
+  smp          = 4
+  vnc_password = "5$zFH9$w"
+
+  cpu_type   = "intel"
+  cpu_model  = "host"
+  hypervisor = "kvm"
+
+  # Hyper-V enlightenments for Windows guests
+  hv_relaxed = true
+  hv_tsc     = true
+}
@@ -0,0 +1,16 @@
+# dual-stack server with DHCP assigned IPv4 and IPv6 addresses
+resource "cloudsigma_server" "web" {
+  cpu          = 2000              # 2GHz CPU
+  memory       = 512 * 1024 * 1024 # 512MB RAM
+  name         = "web"
+  vnc_password = "5$zFH9$w"
+
+  network = [
+    {
+      type = "dhcp"
+      ipv6 = {
+        type = "dhcp"
+      }
+    },
+  ]
+}
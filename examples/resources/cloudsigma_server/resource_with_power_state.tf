@@ -0,0 +1,8 @@
+# cold standby server that is created but not started
+resource "cloudsigma_server" "standby" {
+  cpu          = 2000              # 2GHz CPU
+  memory       = 512 * 1024 * 1024 # 512MB RAM
+  name         = "standby"
+  power_state  = "stopped"
+  vnc_password = "5$zFH9$w"
+}
@@ -11,8 +11,8 @@ resource "cloudsigma_server" "web" {
   name         = "web"
   vnc_password = "5$zFH9$w"
 
-  network {
+  network = [{
     ipv4_address = data.cloudsigma_ip.load_balancer.id
     type         = "static"
-  }
+  }]
 }
@@ -4,11 +4,13 @@ resource "cloudsigma_server" "web" {
   name         = "web"
   vnc_password = "5$zFH9$w"
 
-  network {
-    ipv4_address = "33.44.55.66"
-    type         = "static"
-  }
-  network {
-    vlan_uuid = "<vlan-uuid>"
-  }
+  network = [
+    {
+      ipv4_address = "33.44.55.66"
+      type         = "static"
+    },
+    {
+      vlan_uuid = "<vlan-uuid>"
+    },
+  ]
 }
@@ -0,0 +1,11 @@
+resource "cloudsigma_server" "web" {
+  cpu          = 2000              # 2GHz CPU
+  memory       = 512 * 1024 * 1024 # 512MB RAM
+  name         = "web"
+  vnc_password = "cloudsigma"
+
+  # tags which do not exist yet are created, and deleted with the server
+  # if no other resource uses them
+  delete_tags_on_destroy = true
+  tag_names              = ["production", "web"]
+}
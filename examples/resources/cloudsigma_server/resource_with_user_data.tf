@@ -0,0 +1,13 @@
+# server bootstrapped by cloud-init
+resource "cloudsigma_server" "web" {
+  cpu          = 2000              # 2GHz CPU
+  memory       = 512 * 1024 * 1024 # 512MB RAM
+  name         = "web"
+  vnc_password = "5$zFH9$w"
+
+  user_data = <<-EOT
+    #cloud-config
+    packages:
+      - nginx
+  EOT
+}
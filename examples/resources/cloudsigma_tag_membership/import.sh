@@ -0,0 +1,2 @@
+# Tag membership can be imported using the tag UUID.
+terraform import cloudsigma_tag_membership.production fb44e4e1-84b6-47b6-a9a2-d1d4b09ac2e6
@@ -0,0 +1,16 @@
+resource "cloudsigma_tag" "production" {
+  name = "production"
+}
+
+# a purchased IP address which is not managed by Terraform
+data "cloudsigma_ip" "public" {
+  uuid = "185.12.5.10"
+}
+
+resource "cloudsigma_tag_membership" "production" {
+  tag = cloudsigma_tag.production.id
+  resources = [
+    data.cloudsigma_ip.public.id,
+    "2ae05b0a-c538-47ac-ba41-e8519f782ab4",
+  ]
+}
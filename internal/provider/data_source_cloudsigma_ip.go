@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -28,6 +29,7 @@ type ipDataSourceModel struct {
 	Filters     []migration.FilterModel `tfsdk:"filter"`
 	Gateway     types.String            `tfsdk:"gateway"`
 	ID          types.String            `tfsdk:"id"`
+	IPv6        types.Bool              `tfsdk:"ipv6"`
 	Netmask     types.Int64             `tfsdk:"netmask"`
 	ResourceURI types.String            `tfsdk:"resource_uri"`
 	UUID        types.String            `tfsdk:"uuid"`
@@ -55,6 +57,12 @@ The ip data source provides information about an existing CloudSigma IP address.
 				MarkdownDescription: "The ID of the IP address.",
 				Computed:            true,
 			},
+			"ipv6": schema.BoolAttribute{
+				MarkdownDescription: "Whether the IP address is an IPv6 address. If `uuid` is not set, " +
+					"the only IPv6 (`true`) or IPv4 (`false`) address of the account is looked up.",
+				Computed: true,
+				Optional: true,
+			},
 			"netmask": schema.Int64Attribute{
 				MarkdownDescription: "Netmask value in CIDR notation.",
 				Computed:            true,
@@ -64,9 +72,10 @@ The ip data source provides information about an existing CloudSigma IP address.
 				Computed:            true,
 			},
 			"uuid": schema.StringAttribute{
-				MarkdownDescription: "The unique universal identifier of the current IP address, equal to ID.",
-				Computed:            true,
-				Optional:            true,
+				MarkdownDescription: "The unique universal identifier of the current IP address, equal to ID. " +
+					"This is the IPv4 or IPv6 address itself.",
+				Computed: true,
+				Optional: true,
 			},
 		},
 		Blocks: map[string]schema.Block{
@@ -155,30 +164,66 @@ func (d *ipDataSource) Read(ctx context.Context, request datasource.ReadRequest,
 
 		data.Gateway = types.StringValue(ip.Gateway)
 		data.ID = types.StringValue(ip.UUID)
+		data.IPv6 = types.BoolValue(isIPv6Address(ip.UUID))
 		data.Netmask = types.Int64Value(int64(ip.Netmask))
 		data.ResourceURI = types.StringValue(ip.ResourceURI)
 		data.UUID = types.StringValue(ip.UUID)
 	} else {
 		ipUUID := data.UUID.ValueString()
 
-		if ipUUID == "" {
+		if ipUUID == "" && data.IPv6.IsNull() {
 			response.Diagnostics.AddError(
 				"Missing required attributes",
-				`The attribute "uuid" must be defined.`,
+				`The attribute "uuid" or "ipv6" must be defined.`,
 			)
 			return
 		}
 
-		tflog.Trace(ctx, "Getting IP using UUID", map[string]interface{}{"ip_uuid": ipUUID})
-		ip, _, err := d.client.IPs.Get(ctx, ipUUID)
-		if err != nil {
-			response.Diagnostics.AddError("Unable to get IP", err.Error())
-			return
+		var ip *cloudsigma.IP
+		if ipUUID != "" {
+			tflog.Trace(ctx, "Getting IP using UUID", map[string]interface{}{"ip_uuid": ipUUID})
+			getIP, _, err := d.client.IPs.Get(ctx, ipUUID)
+			if err != nil {
+				response.Diagnostics.AddError("Unable to get IP", err.Error())
+				return
+			}
+			tflog.Trace(ctx, "Got IP", map[string]interface{}{"data": getIP})
+
+			if !data.IPv6.IsNull() && data.IPv6.ValueBool() != isIPv6Address(getIP.UUID) {
+				response.Diagnostics.AddError(
+					"No search results",
+					fmt.Sprintf("The IP address %s does not match the configured \"ipv6\" attribute.", getIP.UUID),
+				)
+				return
+			}
+			ip = getIP
+		} else {
+			tflog.Trace(ctx, "Getting IPs using IP version", map[string]interface{}{"ipv6": data.IPv6.ValueBool()})
+			ips, _, err := d.client.IPs.List(ctx)
+			if err != nil {
+				response.Diagnostics.AddError("Unable to get IPs", err.Error())
+				return
+			}
+			tflog.Trace(ctx, "Got IPs", map[string]interface{}{"ips_count": len(ips)})
+
+			filteredIPs := filterIPsByVersion(ips, data.IPv6.ValueBool())
+			if len(filteredIPs) > 1 {
+				response.Diagnostics.AddError(
+					"Too many search results",
+					fmt.Sprintf("Please refine your search to be more specific with \"uuid\". Found %v IPs.", len(filteredIPs)),
+				)
+				return
+			}
+			if len(filteredIPs) < 1 {
+				response.Diagnostics.AddError("No search results", "Please refine your search.")
+				return
+			}
+			ip = &filteredIPs[0]
 		}
-		tflog.Trace(ctx, "Got IP", map[string]interface{}{"data": ip})
 
 		data.Gateway = types.StringValue(ip.Gateway)
 		data.ID = types.StringValue(ip.UUID)
+		data.IPv6 = types.BoolValue(isIPv6Address(ip.UUID))
 		data.Netmask = types.Int64Value(int64(ip.Netmask))
 		data.ResourceURI = types.StringValue(ip.ResourceURI)
 		data.UUID = types.StringValue(ip.UUID)
@@ -187,3 +232,21 @@ func (d *ipDataSource) Read(ctx context.Context, request datasource.ReadRequest,
 	diags = response.State.Set(ctx, &data)
 	response.Diagnostics.Append(diags...)
 }
+
+// isIPv6Address reports whether address is an IPv6 address.
+func isIPv6Address(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && ip.To4() == nil
+}
+
+// filterIPsByVersion returns the IP addresses which are IPv6 addresses if ipv6
+// is true, or IPv4 addresses otherwise.
+func filterIPsByVersion(ips []cloudsigma.IP, ipv6 bool) []cloudsigma.IP {
+	var filteredIPs []cloudsigma.IP
+	for _, ip := range ips {
+		if isIPv6Address(ip.UUID) == ipv6 {
+			filteredIPs = append(filteredIPs, ip)
+		}
+	}
+	return filteredIPs
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+func TestDataSourceCloudSigmaIP_filterIPsByVersion(t *testing.T) {
+	ips := []cloudsigma.IP{
+		{UUID: "185.12.5.1"},
+		{UUID: "2a03:b0c0::1"},
+		{UUID: "185.12.5.2"},
+	}
+
+	type testCase struct {
+		ips      []cloudsigma.IP
+		ipv6     bool
+		expected []string
+	}
+	tests := map[string]testCase{
+		"ipv4": {
+			ips:      ips,
+			ipv6:     false,
+			expected: []string{"185.12.5.1", "185.12.5.2"},
+		},
+		"ipv6": {
+			ips:      ips,
+			ipv6:     true,
+			expected: []string{"2a03:b0c0::1"},
+		},
+		"no_ipv6": {
+			ips:      ips[:1],
+			ipv6:     true,
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var uuids []string
+			for _, ip := range filterIPsByVersion(test.ips, test.ipv6) {
+				uuids = append(uuids, ip.UUID)
+			}
+			assert.Equal(t, test.expected, uuids)
+		})
+	}
+}
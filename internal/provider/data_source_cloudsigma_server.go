@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/server"
+)
+
+var (
+	_ datasource.DataSource              = (*serverDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*serverDataSource)(nil)
+)
+
+// serverDataSource is the server data source implementation.
+type serverDataSource struct {
+	client *cloudsigma.Client
+}
+
+// serverDataSourceModel maps the server data source schema data.
+type serverDataSourceModel struct {
+	CPU           types.Int64  `tfsdk:"cpu"`
+	CPUType       types.String `tfsdk:"cpu_type"`
+	Drives        types.List   `tfsdk:"drive"`
+	Hypervisor    types.String `tfsdk:"hypervisor"`
+	ID            types.String `tfsdk:"id"`
+	IPv4Address   types.String `tfsdk:"ipv4_address"`
+	IPv4Addresses types.List   `tfsdk:"ipv4_addresses"`
+	IPv6Address   types.String `tfsdk:"ipv6_address"`
+	IPv6Addresses types.List   `tfsdk:"ipv6_addresses"`
+	Memory        types.Int64  `tfsdk:"memory"`
+	Meta          types.Map    `tfsdk:"meta"`
+	Name          types.String `tfsdk:"name"`
+	ResourceURI   types.String `tfsdk:"resource_uri"`
+	SMP           types.Int64  `tfsdk:"smp"`
+	Status        types.String `tfsdk:"status"`
+	Tags          types.Set    `tfsdk:"tags"`
+	UUID          types.String `tfsdk:"uuid"`
+}
+
+func NewServerDataSource() datasource.DataSource {
+	return &serverDataSource{}
+}
+
+func (d *serverDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "cloudsigma_server"
+}
+
+func (d *serverDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	attributes := serverDataSourceAttributes()
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "The human readable name of the server. It must be unique if the server is looked up by name.",
+		Computed:            true,
+		Optional:            true,
+	}
+	attributes["uuid"] = schema.StringAttribute{
+		MarkdownDescription: "The unique universal identifier of the current server, equal to ID.",
+		Computed:            true,
+		Optional:            true,
+	}
+
+	response.Schema = schema.Schema{
+		MarkdownDescription: `
+The server data source provides information about an existing CloudSigma server.
+`,
+		Attributes: attributes,
+	}
+}
+
+func (d *serverDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*cloudsigma.Client)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unconfigured CloudSigma client",
+			"Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *serverDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data serverDataSourceModel
+
+	// read state data into the model
+	diags := request.Config.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	serverName := data.Name.ValueString()
+	serverUUID := data.UUID.ValueString()
+
+	if serverName == "" && serverUUID == "" {
+		response.Diagnostics.AddError(
+			"Missing required attributes",
+			`The attribute "name" or "uuid" must be defined.`,
+		)
+		return
+	}
+
+	var srv *cloudsigma.Server
+	if serverUUID != "" {
+		tflog.Trace(ctx, "Getting server using UUID", map[string]interface{}{"server_uuid": serverUUID})
+		s, resp, err := d.client.Servers.Get(ctx, serverUUID)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				response.Diagnostics.AddError("No search results", "Please refine your search.")
+				return
+			}
+			response.Diagnostics.AddError("Unable to get server", err.Error())
+			return
+		}
+		tflog.Trace(ctx, "Got server", map[string]interface{}{"data": s})
+
+		// if name is defined check that it's equal
+		if serverName != "" && serverName != s.Name {
+			response.Diagnostics.AddError(
+				"Ambiguous search result",
+				fmt.Sprintf("Specified and actual server name are different. Expected '%s', got '%s'", serverName, s.Name),
+			)
+			return
+		}
+		srv = s
+	} else {
+		tflog.Trace(ctx, "Getting servers")
+		servers, _, err := server.List(ctx, d.client)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to get servers", err.Error())
+			return
+		}
+		tflog.Trace(ctx, "Got servers", map[string]interface{}{"servers_count": len(servers)})
+
+		var found []cloudsigma.Server
+		for _, s := range servers {
+			if s.Name == serverName {
+				found = append(found, s)
+			}
+		}
+
+		if len(found) > 1 {
+			response.Diagnostics.AddError(
+				"Too many search results",
+				fmt.Sprintf("Please refine your search to be more specific. Found %v servers.", len(found)),
+			)
+			return
+		}
+		if len(found) < 1 {
+			response.Diagnostics.AddError("No search results", "Please refine your search.")
+			return
+		}
+		srv = &found[0]
+	}
+
+	// map response body to attributes
+	item, diags := flattenServerDataSourceItem(ctx, *srv)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	data.CPU = item.CPU
+	data.CPUType = item.CPUType
+	data.Drives = item.Drives
+	data.Hypervisor = item.Hypervisor
+	data.ID = item.ID
+	data.IPv4Address = item.IPv4Address
+	data.IPv4Addresses = item.IPv4Addresses
+	data.IPv6Address = item.IPv6Address
+	data.IPv6Addresses = item.IPv6Addresses
+	data.Memory = item.Memory
+	data.Meta = item.Meta
+	data.Name = item.Name
+	data.ResourceURI = item.ResourceURI
+	data.SMP = item.SMP
+	data.Status = item.Status
+	data.Tags = item.Tags
+	data.UUID = item.ID
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+// serverDataSourceItemModel maps the server attributes shared by the server
+// and the servers data sources.
+type serverDataSourceItemModel struct {
+	CPU           types.Int64  `tfsdk:"cpu"`
+	CPUType       types.String `tfsdk:"cpu_type"`
+	Drives        types.List   `tfsdk:"drive"`
+	Hypervisor    types.String `tfsdk:"hypervisor"`
+	ID            types.String `tfsdk:"id"`
+	IPv4Address   types.String `tfsdk:"ipv4_address"`
+	IPv4Addresses types.List   `tfsdk:"ipv4_addresses"`
+	IPv6Address   types.String `tfsdk:"ipv6_address"`
+	IPv6Addresses types.List   `tfsdk:"ipv6_addresses"`
+	Memory        types.Int64  `tfsdk:"memory"`
+	Meta          types.Map    `tfsdk:"meta"`
+	Name          types.String `tfsdk:"name"`
+	ResourceURI   types.String `tfsdk:"resource_uri"`
+	SMP           types.Int64  `tfsdk:"smp"`
+	Status        types.String `tfsdk:"status"`
+	Tags          types.Set    `tfsdk:"tags"`
+}
+
+// serverDataSourceAttributes returns the schema of the server attributes
+// shared by the server and the servers data sources.
+func serverDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"cpu": schema.Int64Attribute{
+			MarkdownDescription: "Server's CPU Clock speed measured in MHz.",
+			Computed:            true,
+		},
+		"cpu_type": schema.StringAttribute{
+			MarkdownDescription: "The type of the host CPU the server runs on.",
+			Computed:            true,
+		},
+		"drive": schema.ListNestedAttribute{
+			MarkdownDescription: "Drives attached to the server, ordered by their boot order.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"boot_order": schema.Int64Attribute{
+						MarkdownDescription: "The boot order of the drive.",
+						Computed:            true,
+					},
+					"dev_channel": schema.StringAttribute{
+						MarkdownDescription: "The device channel of the drive.",
+						Computed:            true,
+					},
+					"device": schema.StringAttribute{
+						MarkdownDescription: "Device emulation type.",
+						Computed:            true,
+					},
+					"uuid": schema.StringAttribute{
+						MarkdownDescription: "The UUID of the drive.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+		"hypervisor": schema.StringAttribute{
+			MarkdownDescription: "The hypervisor the server runs on.",
+			Computed:            true,
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the server.",
+			Computed:            true,
+		},
+		"ipv4_address": schema.StringAttribute{
+			MarkdownDescription: "The public IPv4 address of the running server.",
+			Computed:            true,
+		},
+		"ipv4_addresses": schema.ListAttribute{
+			MarkdownDescription: "All public and private IPv4 addresses of the running server, " +
+				"in the order of its network interface cards.",
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"ipv6_address": schema.StringAttribute{
+			MarkdownDescription: "The public IPv6 address of the running server.",
+			Computed:            true,
+		},
+		"ipv6_addresses": schema.ListAttribute{
+			MarkdownDescription: "All public and private IPv6 addresses of the running server, " +
+				"in the order of its network interface cards.",
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"memory": schema.Int64Attribute{
+			MarkdownDescription: "Server's RAM measured in bytes.",
+			Computed:            true,
+		},
+		"meta": schema.MapAttribute{
+			MarkdownDescription: "The arbitrary information of the server stored in key-value form.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The human readable name of the server.",
+			Computed:            true,
+		},
+		"resource_uri": schema.StringAttribute{
+			MarkdownDescription: "The unique resource identifier of the server.",
+			Computed:            true,
+		},
+		"smp": schema.Int64Attribute{
+			MarkdownDescription: "Symmetric Multiprocessing (SMP) i.e. number of CPU cores.",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "The current status of the server.",
+			Computed:            true,
+		},
+		"tags": schema.SetAttribute{
+			MarkdownDescription: "The UUIDs of the tags applied to the server.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+// flattenServerDataSourceItem maps the server to the attributes shared by the
+// server and the servers data sources.
+func flattenServerDataSourceItem(ctx context.Context, srv cloudsigma.Server) (serverDataSourceItemModel, diag.Diagnostics) {
+	var diags, d diag.Diagnostics
+
+	item := serverDataSourceItemModel{
+		CPU:         types.Int64Value(int64(srv.CPU)),
+		CPUType:     stringValueOrNull(srv.CPUType),
+		Hypervisor:  stringValueOrNull(srv.Hypervisor),
+		ID:          types.StringValue(srv.UUID),
+		IPv4Address: stringValueOrNull(findIPv4Address(&srv, "public")),
+		IPv6Address: stringValueOrNull(findIPv6Address(&srv, "public")),
+		Memory:      types.Int64Value(int64(srv.Memory)),
+		Name:        types.StringValue(srv.Name),
+		ResourceURI: types.StringValue(srv.ResourceURI),
+		SMP:         types.Int64Value(int64(srv.SMP)),
+		Status:      types.StringValue(srv.Status),
+	}
+
+	item.Drives, d = flattenServerDrives(ctx, srv.Drives)
+	diags.Append(d...)
+	item.Meta, d = types.MapValueFrom(ctx, types.StringType, flattenServerMeta(srv.Meta, nil, nil))
+	diags.Append(d...)
+	ipv4Addresses, ipv6Addresses := serverRuntimeIPAddresses(&srv)
+	item.IPv4Addresses, d = types.ListValueFrom(ctx, types.StringType, ipv4Addresses)
+	diags.Append(d...)
+	item.IPv6Addresses, d = types.ListValueFrom(ctx, types.StringType, ipv6Addresses)
+	diags.Append(d...)
+	item.Tags, d = flattenTags(ctx, srv.Tags)
+	diags.Append(d...)
+
+	return item, diags
+}
+
+// serverRuntimeIPAddresses returns the IPv4 and IPv6 addresses of all network
+// interface cards of the running server.
+func serverRuntimeIPAddresses(srv *cloudsigma.Server) ([]string, []string) {
+	ipv4Addresses := make([]string, 0)
+	ipv6Addresses := make([]string, 0)
+	if srv.Runtime == nil {
+		return ipv4Addresses, ipv6Addresses
+	}
+
+	for _, nic := range srv.Runtime.RuntimeNICs {
+		if nic.IPv4.UUID != "" {
+			ipv4Addresses = append(ipv4Addresses, nic.IPv4.UUID)
+		}
+		if nic.IPv6.UUID != "" {
+			ipv6Addresses = append(ipv6Addresses, nic.IPv6.UUID)
+		}
+	}
+
+	return ipv4Addresses, ipv6Addresses
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceCloudSigmaServer_name(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaServerDataSourceWithName(serverName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.ds_foobar_name", &srv),
+					resource.TestCheckResourceAttr("data.cloudsigma_server.ds_foobar_name", "name", serverName),
+					resource.TestCheckResourceAttr("data.cloudsigma_server.ds_foobar_name", "cpu", "2000"),
+					resource.TestCheckResourceAttr("data.cloudsigma_server.ds_foobar_name", "memory", "536870912"),
+					resource.TestCheckResourceAttrPair("data.cloudsigma_server.ds_foobar_name", "id", "cloudsigma_server.ds_foobar_name", "id"),
+					resource.TestCheckResourceAttrPair("data.cloudsigma_server.ds_foobar_name", "ipv4_address", "cloudsigma_server.ds_foobar_name", "ipv4_address"),
+					resource.TestCheckResourceAttrSet("data.cloudsigma_server.ds_foobar_name", "status"),
+					resource.TestCheckResourceAttrSet("data.cloudsigma_server.ds_foobar_name", "uuid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceCloudSigmaServer_uuid(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaServerDataSourceWithUUID(serverName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.ds_foobar_uuid", &srv),
+					resource.TestCheckResourceAttr("data.cloudsigma_server.ds_foobar_uuid", "name", serverName),
+					resource.TestCheckResourceAttrPair("data.cloudsigma_server.ds_foobar_uuid", "id", "cloudsigma_server.ds_foobar_uuid", "id"),
+					resource.TestCheckResourceAttrSet("data.cloudsigma_server.ds_foobar_uuid", "status"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceCloudSigmaServer_expectError(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudSigmaServerDataSourceWithoutNameAndUUID(),
+				ExpectError: regexp.MustCompile(`The attribute "name" or "uuid" must be defined.`),
+			},
+		},
+	})
+}
+
+func TestDataSourceCloudSigmaServer_serverRuntimeIPAddresses(t *testing.T) {
+	type testCase struct {
+		server       *cloudsigma.Server
+		expectedIPv4 []string
+		expectedIPv6 []string
+	}
+	tests := map[string]testCase{
+		"without_runtime": {
+			server:       &cloudsigma.Server{},
+			expectedIPv4: []string{},
+			expectedIPv6: []string{},
+		},
+		"multiple_nics": {
+			server: &cloudsigma.Server{
+				Runtime: &cloudsigma.ServerRuntime{
+					RuntimeNICs: []cloudsigma.ServerRuntimeNIC{
+						{
+							InterfaceType: "public",
+							IPv4:          cloudsigma.ServerRuntimeIP{UUID: "178.33.44.55"},
+							IPv6:          cloudsigma.ServerRuntimeIP{UUID: "2a03:b0c0::1"},
+						},
+						{InterfaceType: "private"},
+						{InterfaceType: "public", IPv4: cloudsigma.ServerRuntimeIP{UUID: "178.33.44.56"}},
+						{InterfaceType: "private", IPv4: cloudsigma.ServerRuntimeIP{UUID: "10.1.1.1"}},
+					},
+				},
+			},
+			expectedIPv4: []string{"178.33.44.55", "178.33.44.56", "10.1.1.1"},
+			expectedIPv6: []string{"2a03:b0c0::1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ipv4Addresses, ipv6Addresses := serverRuntimeIPAddresses(test.server)
+
+			assert.Equal(t, test.expectedIPv4, ipv4Addresses)
+			assert.Equal(t, test.expectedIPv6, ipv6Addresses)
+		})
+	}
+}
+
+func testAccCloudSigmaServerDataSourceWithName(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "ds_foobar_name" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+}
+
+data "cloudsigma_server" "ds_foobar_name" {
+  name = cloudsigma_server.ds_foobar_name.name
+}
+`, name)
+}
+
+func testAccCloudSigmaServerDataSourceWithUUID(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "ds_foobar_uuid" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+}
+
+data "cloudsigma_server" "ds_foobar_uuid" {
+  uuid = cloudsigma_server.ds_foobar_uuid.id
+}
+`, name)
+}
+
+func testAccCloudSigmaServerDataSourceWithoutNameAndUUID() string {
+	return `
+data "cloudsigma_server" "ds_foobar_without_name_and_uuid" {
+  name = ""
+  uuid = ""
+}
+`
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/server"
+)
+
+var (
+	_ datasource.DataSource              = (*serversDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*serversDataSource)(nil)
+)
+
+// serversDataSource is the servers data source implementation.
+type serversDataSource struct {
+	client *cloudsigma.Client
+}
+
+// serversDataSourceModel maps the servers data source schema data.
+type serversDataSourceModel struct {
+	NamePrefix types.String                `tfsdk:"name_prefix"`
+	Servers    []serverDataSourceItemModel `tfsdk:"servers"`
+	Status     types.String                `tfsdk:"status"`
+	Tags       types.Set                   `tfsdk:"tags"`
+}
+
+func NewServersDataSource() datasource.DataSource {
+	return &serversDataSource{}
+}
+
+func (d *serversDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = "cloudsigma_servers"
+}
+
+func (d *serversDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: `
+The servers data source provides information about all CloudSigma servers matching the given criteria.
+`,
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only servers with a name starting with this prefix are returned.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"servers": schema.ListNestedAttribute{
+				MarkdownDescription: "The servers matching all criteria, ordered by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: serverDataSourceAttributes(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only servers with this status are returned, e.g. `running` or `stopped`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Only servers with all of these tag UUIDs are returned.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+func (d *serversDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*cloudsigma.Client)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unconfigured CloudSigma client",
+			"Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *serversDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data serversDataSourceModel
+
+	// read state data into the model
+	diags := request.Config.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []string
+	diags = data.Tags.ElementsAs(ctx, &tags, false)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Getting servers")
+	servers, _, err := server.List(ctx, d.client)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get servers", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Got servers", map[string]interface{}{"servers_count": len(servers)})
+
+	// map response body to attributes
+	data.Servers, diags = flattenServersDataSourceItems(ctx, filterServers(servers, data.NamePrefix.ValueString(), data.Status.ValueString(), tags))
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+// filterServers returns the servers matching the name prefix, the status and
+// all the tags, ordered by name. Empty criteria match all servers.
+func filterServers(servers []cloudsigma.Server, namePrefix, status string, tags []string) []cloudsigma.Server {
+	filtered := make([]cloudsigma.Server, 0, len(servers))
+	for _, srv := range servers {
+		if !strings.HasPrefix(srv.Name, namePrefix) {
+			continue
+		}
+		if status != "" && srv.Status != status {
+			continue
+		}
+		if !serverHasTags(srv, tags) {
+			continue
+		}
+		filtered = append(filtered, srv)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+
+	return filtered
+}
+
+// serverHasTags reports whether all the tags are applied to the server.
+func serverHasTags(srv cloudsigma.Server, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(srv.Tags, func(t cloudsigma.Tag) bool { return t.UUID == tag }) {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenServersDataSourceItems maps the servers to the items of the servers
+// data source.
+func flattenServersDataSourceItems(ctx context.Context, servers []cloudsigma.Server) ([]serverDataSourceItemModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	items := make([]serverDataSourceItemModel, 0, len(servers))
+	for _, srv := range servers {
+		item, d := flattenServerDataSourceItem(ctx, srv)
+		diags.Append(d...)
+		items = append(items, item)
+	}
+
+	return items, diags
+}
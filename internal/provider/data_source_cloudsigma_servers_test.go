@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceCloudSigmaServers_basic(t *testing.T) {
+	namePrefix := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaServersDataSource(namePrefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsigma_servers.ds_foobar", "servers.#", "2"),
+					resource.TestCheckResourceAttr("data.cloudsigma_servers.ds_foobar", "servers.0.name", namePrefix+"-0"),
+					resource.TestCheckResourceAttr("data.cloudsigma_servers.ds_foobar", "servers.1.name", namePrefix+"-1"),
+					resource.TestCheckResourceAttr("data.cloudsigma_servers.ds_foobar", "servers.0.cpu", "2000"),
+					resource.TestCheckResourceAttr("data.cloudsigma_servers.ds_foobar", "servers.0.status", "running"),
+					resource.TestCheckResourceAttrPair("data.cloudsigma_servers.ds_foobar", "servers.0.ipv4_addresses.0", "data.cloudsigma_servers.ds_foobar", "servers.0.ipv4_address"),
+					resource.TestCheckResourceAttr("data.cloudsigma_servers.ds_foobar_tagged", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.cloudsigma_servers.ds_foobar_tagged", "servers.0.name", namePrefix+"-0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceCloudSigmaServers_filterServers(t *testing.T) {
+	servers := []cloudsigma.Server{
+		{Name: "web-2", Status: "running", Tags: []cloudsigma.Tag{{UUID: "tag-a"}}},
+		{Name: "db-1", Status: "running", Tags: []cloudsigma.Tag{{UUID: "tag-a"}, {UUID: "tag-b"}}},
+		{Name: "web-1", Status: "stopped"},
+	}
+
+	type testCase struct {
+		namePrefix string
+		status     string
+		tags       []string
+		expected   []string
+	}
+	tests := map[string]testCase{
+		"all": {
+			expected: []string{"db-1", "web-1", "web-2"},
+		},
+		"name_prefix": {
+			namePrefix: "web-",
+			expected:   []string{"web-1", "web-2"},
+		},
+		"status": {
+			status:   "running",
+			expected: []string{"db-1", "web-2"},
+		},
+		"all_tags": {
+			tags:     []string{"tag-a", "tag-b"},
+			expected: []string{"db-1"},
+		},
+		"combined": {
+			namePrefix: "web-",
+			status:     "running",
+			tags:       []string{"tag-a"},
+			expected:   []string{"web-2"},
+		},
+		"no_match": {
+			namePrefix: "app-",
+			expected:   []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := make([]string, 0)
+			for _, srv := range filterServers(servers, test.namePrefix, test.status, test.tags) {
+				actual = append(actual, srv.Name)
+			}
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func testAccCloudSigmaServersDataSource(namePrefix string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_tag" "ds_foobar" {
+  name = "%[1]s"
+}
+
+resource "cloudsigma_server" "ds_foobar" {
+  count = 2
+
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%[1]s-${count.index}"
+  vnc_password = "VnC!Pa33w0rd"
+
+  tags = count.index == 0 ? [cloudsigma_tag.ds_foobar.id] : []
+}
+
+data "cloudsigma_servers" "ds_foobar" {
+  name_prefix = "%[1]s"
+  status      = "running"
+
+  depends_on = [cloudsigma_server.ds_foobar]
+}
+
+data "cloudsigma_servers" "ds_foobar_tagged" {
+  name_prefix = "%[1]s"
+  tags        = [cloudsigma_tag.ds_foobar.id]
+
+  depends_on = [cloudsigma_server.ds_foobar]
+}
+`, namePrefix)
+}
@@ -2,10 +2,15 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -25,11 +30,27 @@ type tagDataSource struct {
 
 // tagDataSourceModel maps the tag data source schema data.
 type tagDataSourceModel struct {
-	Filters     []migration.FilterModel `tfsdk:"filter"`
-	ID          types.String            `tfsdk:"id"`
-	Name        types.String            `tfsdk:"name"`
-	ResourceURI types.String            `tfsdk:"resource_uri"`
-	UUID        types.String            `tfsdk:"uuid"`
+	Filters      []migration.FilterModel `tfsdk:"filter"`
+	ID           types.String            `tfsdk:"id"`
+	Meta         types.Map               `tfsdk:"meta"`
+	Name         types.String            `tfsdk:"name"`
+	ResourceType types.String            `tfsdk:"resource_type"`
+	ResourceURI  types.String            `tfsdk:"resource_uri"`
+	Resources    types.List              `tfsdk:"resources"`
+	UUID         types.String            `tfsdk:"uuid"`
+}
+
+// tagDataSourceResourceModel maps the resources of the tag data source.
+type tagDataSourceResourceModel struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceURI  types.String `tfsdk:"resource_uri"`
+	UUID         types.String `tfsdk:"uuid"`
+}
+
+var tagDataSourceResourceAttrTypes = map[string]attr.Type{
+	"resource_type": types.StringType,
+	"resource_uri":  types.StringType,
+	"uuid":          types.StringType,
 }
 
 func NewTagDataSource() datasource.DataSource {
@@ -50,15 +71,48 @@ The tag data source provides information about an existing CloudSigma tag.
 				MarkdownDescription: "The ID of the tag.",
 				Computed:            true,
 			},
+			"meta": schema.MapAttribute{
+				MarkdownDescription: "The arbitrary information of the tag stored in key-value form. " +
+					"Values which are not strings, e.g. numbers or objects, are JSON-encoded.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of the tag.",
 				Computed:            true,
 				Optional:            true,
 			},
+			"resource_type": schema.StringAttribute{
+				MarkdownDescription: "Only resources of this type are returned in `resources`, e.g. `drives`, `servers`, `ips` or `vlans`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"resource_uri": schema.StringAttribute{
 				MarkdownDescription: "The unique resource identifier of the tag.",
 				Computed:            true,
 			},
+			"resources": schema.ListNestedAttribute{
+				MarkdownDescription: "The resources the tag is applied to.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the resource, e.g. `drives` or `servers`.",
+							Computed:            true,
+						},
+						"resource_uri": schema.StringAttribute{
+							MarkdownDescription: "The unique resource identifier of the resource.",
+							Computed:            true,
+						},
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "The UUID of the resource.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 			"uuid": schema.StringAttribute{
 				MarkdownDescription: "The unique universal identifier of the current tag, equal to ID.",
 				Computed:            true,
@@ -149,10 +203,7 @@ func (d *tagDataSource) Read(ctx context.Context, request datasource.ReadRequest
 
 		tag := filteredTags[0].(cloudsigma.Tag)
 
-		data.ID = types.StringValue(tag.UUID)
-		data.Name = types.StringValue(tag.Name)
-		data.ResourceURI = types.StringValue(tag.ResourceURI)
-		data.UUID = types.StringValue(tag.UUID)
+		response.Diagnostics.Append(flattenTagDataSource(ctx, &tag, &data)...)
 	} else {
 		tagUUID := data.UUID.ValueString()
 		tagName := data.Name.ValueString()
@@ -183,10 +234,7 @@ func (d *tagDataSource) Read(ctx context.Context, request datasource.ReadRequest
 				return
 			}
 
-			data.ID = types.StringValue(tag.UUID)
-			data.Name = types.StringValue(tag.Name)
-			data.ResourceURI = types.StringValue(tag.ResourceURI)
-			data.UUID = types.StringValue(tag.UUID)
+			response.Diagnostics.Append(flattenTagDataSource(ctx, tag, &data)...)
 		} else {
 			tflog.Trace(ctx, "Getting tags for filtering", map[string]interface{}{"tag_name": tagName})
 			tags, _, err := d.client.Tags.List(ctx)
@@ -199,10 +247,7 @@ func (d *tagDataSource) Read(ctx context.Context, request datasource.ReadRequest
 			tagFound := false
 			for _, tag := range tags {
 				if tagName == tag.Name {
-					data.ID = types.StringValue(tag.UUID)
-					data.Name = types.StringValue(tag.Name)
-					data.ResourceURI = types.StringValue(tag.ResourceURI)
-					data.UUID = types.StringValue(tag.UUID)
+					response.Diagnostics.Append(flattenTagDataSource(ctx, &tag, &data)...)
 
 					tagFound = true
 					break
@@ -216,6 +261,54 @@ func (d *tagDataSource) Read(ctx context.Context, request datasource.ReadRequest
 		}
 	}
 
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	diags = response.State.Set(ctx, &data)
 	response.Diagnostics.Append(diags...)
 }
+
+// flattenTagDataSource maps the tag to the data source attributes. Only the
+// resources of the configured resource_type are listed.
+func flattenTagDataSource(ctx context.Context, tag *cloudsigma.Tag, data *tagDataSourceModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	data.ID = types.StringValue(tag.UUID)
+	data.Name = types.StringValue(tag.Name)
+	data.ResourceURI = types.StringValue(tag.ResourceURI)
+	data.UUID = types.StringValue(tag.UUID)
+
+	// meta values which are not strings are JSON-encoded
+	meta := make(map[string]string, len(tag.Meta))
+	for k, v := range tag.Meta {
+		if s, ok := v.(string); ok {
+			meta[k] = s
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			diags.AddError("Unable to encode tag meta", fmt.Sprintf("meta key %q: %s", k, err))
+			continue
+		}
+		meta[k] = string(encoded)
+	}
+	data.Meta, d = types.MapValueFrom(ctx, types.StringType, meta)
+	diags.Append(d...)
+
+	resources := make([]tagDataSourceResourceModel, 0, len(tag.Resources))
+	for _, resource := range tag.Resources {
+		if !data.ResourceType.IsNull() && data.ResourceType.ValueString() != resource.ResourceType {
+			continue
+		}
+		resources = append(resources, tagDataSourceResourceModel{
+			ResourceType: types.StringValue(resource.ResourceType),
+			ResourceURI:  types.StringValue(resource.ResourceURI),
+			UUID:         types.StringValue(resource.UUID),
+		})
+	}
+	data.Resources, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tagDataSourceResourceAttrTypes}, resources)
+	diags.Append(d...)
+
+	return diags
+}
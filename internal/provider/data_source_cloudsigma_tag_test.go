@@ -1,13 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
 
 	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccDataSourceCloudSigmaTag_basic(t *testing.T) {
@@ -82,6 +85,34 @@ func TestAccDataSourceCloudSigmaTag_filter(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceCloudSigmaTag_resources(t *testing.T) {
+	tagName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckTagDestroy,
+			testAccCheckDriveDestroy,
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaTagDataSourceWithResources(tagName, driveName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsigma_tag.ds_foobar_drives", "resources.#", "1"),
+					resource.TestCheckResourceAttrPair("data.cloudsigma_tag.ds_foobar_drives", "resources.0.uuid", "cloudsigma_drive.ds_foobar_resources", "id"),
+					resource.TestCheckResourceAttr("data.cloudsigma_tag.ds_foobar_drives", "resources.0.resource_type", "drives"),
+					resource.TestCheckResourceAttrSet("data.cloudsigma_tag.ds_foobar_drives", "resources.0.resource_uri"),
+					resource.TestCheckResourceAttr("data.cloudsigma_tag.ds_foobar_drives", "meta.%", "0"),
+					resource.TestCheckResourceAttr("data.cloudsigma_tag.ds_foobar_servers", "resources.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDataSourceCloudSigmaTag_expectError(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -97,6 +128,70 @@ func TestAccDataSourceCloudSigmaTag_expectError(t *testing.T) {
 	})
 }
 
+func TestDataSourceCloudSigmaTag_flattenTagDataSource(t *testing.T) {
+	ctx := context.Background()
+	tag := &cloudsigma.Tag{
+		Meta: map[string]interface{}{
+			"backup":  "nightly",
+			"count":   1,
+			"enabled": true,
+			"window":  map[string]interface{}{"start": "02:00"},
+		},
+		Name: "backup",
+		Resources: []cloudsigma.TagResource{
+			{ResourceType: "drives", ResourceURI: "/api/2.0/drives/drive-1/", UUID: "drive-1"},
+			{ResourceType: "servers", ResourceURI: "/api/2.0/servers/server-1/", UUID: "server-1"},
+			{ResourceType: "drives", ResourceURI: "/api/2.0/drives/drive-2/", UUID: "drive-2"},
+		},
+		UUID: "tag-1",
+	}
+
+	type testCase struct {
+		resourceType types.String
+		expected     []string
+	}
+	tests := map[string]testCase{
+		"all": {
+			resourceType: types.StringNull(),
+			expected:     []string{"drive-1", "server-1", "drive-2"},
+		},
+		"drives": {
+			resourceType: types.StringValue("drives"),
+			expected:     []string{"drive-1", "drive-2"},
+		},
+		"no_match": {
+			resourceType: types.StringValue("vlans"),
+			expected:     []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := tagDataSourceModel{ResourceType: test.resourceType}
+			diags := flattenTagDataSource(ctx, tag, &data)
+			assert.False(t, diags.HasError(), diags)
+
+			var resources []tagDataSourceResourceModel
+			assert.False(t, data.Resources.ElementsAs(ctx, &resources, false).HasError())
+			actual := make([]string, 0, len(resources))
+			for _, r := range resources {
+				actual = append(actual, r.UUID.ValueString())
+			}
+			assert.Equal(t, test.expected, actual)
+
+			var meta map[string]string
+			assert.False(t, data.Meta.ElementsAs(ctx, &meta, false).HasError())
+			assert.Equal(t, map[string]string{
+				"backup":  "nightly",
+				"count":   "1",
+				"enabled": "true",
+				"window":  `{"start":"02:00"}`,
+			}, meta)
+			assert.Equal(t, "tag-1", data.ID.ValueString())
+		})
+	}
+}
+
 func testAccCloudSigmaTagDataSource(name string) string {
 	return fmt.Sprintf(`
 resource "cloudsigma_tag" "ds_foobar_basic" {
@@ -136,6 +231,32 @@ data "cloudsigma_tag" "ds_foobar_filter" {
 `, name)
 }
 
+func testAccCloudSigmaTagDataSourceWithResources(tagName, driveName string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_tag" "ds_foobar_resources" {
+  name = "%s"
+}
+
+resource "cloudsigma_drive" "ds_foobar_resources" {
+  media = "disk"
+  name  = "%s"
+  size  = 5 * 1024 * 1024 * 1024
+
+  tags = [cloudsigma_tag.ds_foobar_resources.id]
+}
+
+data "cloudsigma_tag" "ds_foobar_drives" {
+  uuid          = one(cloudsigma_drive.ds_foobar_resources.tags_all)
+  resource_type = "drives"
+}
+
+data "cloudsigma_tag" "ds_foobar_servers" {
+  uuid          = one(cloudsigma_drive.ds_foobar_resources.tags_all)
+  resource_type = "servers"
+}
+`, tagName, driveName)
+}
+
 func testAccCloudSigmaTagDataSourceWithoutNameAndUUID() string {
 	return `
 data "cloudsigma_tag" "ds_foobar_without_name_and_uuid" {
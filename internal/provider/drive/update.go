@@ -0,0 +1,38 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// updateRequest represents a request to update a drive. Unlike the SDK, it
+// always sends the tags, so that an empty list removes all tags of the drive.
+type updateRequest struct {
+	*cloudsigma.Drive
+	Tags []cloudsigma.Tag `json:"tags"`
+}
+
+// Update updates the drive identified by driveUUID and returns the updated
+// drive.
+func Update(ctx context.Context, client *cloudsigma.Client, driveUUID string, d *cloudsigma.Drive) (*cloudsigma.Drive, *cloudsigma.Response, error) {
+	request := &updateRequest{Drive: d, Tags: d.Tags}
+	if request.Tags == nil {
+		request.Tags = []cloudsigma.Tag{}
+	}
+
+	req, err := client.NewRequest(http.MethodPut, fmt.Sprintf("drives/%s/", driveUUID), request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(cloudsigma.Drive)
+	resp, err := client.Do(ctx, req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 
 	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/wait"
 )
 
 func WaitDriveStatusMountedOrUnmounted(ctx context.Context, client *cloudsigma.Client, driveUUID string) error {
@@ -14,7 +15,7 @@ func WaitDriveStatusMountedOrUnmounted(ctx context.Context, client *cloudsigma.C
 		Pending:    []string{driveStatusCloning, driveStatusCreating, driveStatusResizing},
 		Target:     []string{driveStatusMounted, driveStatusUnmounted},
 		Refresh:    statusDriveStatus(ctx, client, driveUUID),
-		Timeout:    10 * time.Minute,
+		Timeout:    wait.Timeout(ctx),
 		MinTimeout: 5 * time.Second,
 		Delay:      3 * time.Second,
 	}
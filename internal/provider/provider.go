@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
@@ -53,6 +54,12 @@ func (p *cloudSigmaProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				DeprecationMessage: `This "base_url" attribute is unused and will be removed in a future version of the provider. ` +
 					"Please use location to specify CloudSigma API endpoint if needed: https://docs.cloudsigma.com/en/latest/general.html#api-endpoint.",
 			},
+			"ignore_meta_keys": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Server meta keys which are ignored by all cloudsigma_server resources, e.g. keys managed by agents inside the guest. " +
+					"Ignored keys are not reported in 'meta' and their values are kept on update.",
+			},
 			"location": schema.StringAttribute{
 				Optional:    true,
 				Description: fmt.Sprintf("The location endpoint for CloudSigma. Default is '%s'.", defaultLocation),
@@ -72,15 +79,43 @@ func (p *cloudSigmaProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Description: "The CloudSigma user email.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags applied to all servers, drives and snapshots. They are merged into the tags of each resource " +
+					"and only listed in its 'tags_all' attribute.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.SetAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "A list of the tags UUIDs to be applied to all servers, drives and snapshots.",
+					},
+				},
+			},
+		},
 	}
 }
 
 type providerModel struct {
-	BaseURL  types.String `tfsdk:"base_url"`
-	Location types.String `tfsdk:"location"`
-	Password types.String `tfsdk:"password"`
-	Token    types.String `tfsdk:"token"`
-	Username types.String `tfsdk:"username"`
+	BaseURL        types.String `tfsdk:"base_url"`
+	DefaultTags    types.Object `tfsdk:"default_tags"`
+	IgnoreMetaKeys types.Set    `tfsdk:"ignore_meta_keys"`
+	Location       types.String `tfsdk:"location"`
+	Password       types.String `tfsdk:"password"`
+	Token          types.String `tfsdk:"token"`
+	Username       types.String `tfsdk:"username"`
+}
+
+// providerResourceData is passed to the resources, it holds the client and
+// the provider settings which apply to the resources.
+type providerResourceData struct {
+	client         *cloudsigma.Client
+	defaultTags    []string
+	ignoreMetaKeys []string
+}
+
+// providerDefaultTagsModel maps the default_tags block of the provider.
+type providerDefaultTagsModel struct {
+	Tags types.Set `tfsdk:"tags"`
 }
 
 func (p *cloudSigmaProvider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
@@ -156,6 +191,21 @@ func (p *cloudSigmaProvider) Configure(ctx context.Context, request provider.Con
 		return
 	}
 
+	var ignoreMetaKeys []string
+	diags = config.IgnoreMetaKeys.ElementsAs(ctx, &ignoreMetaKeys, false)
+	response.Diagnostics.Append(diags...)
+	var defaultTags []string
+	if !config.DefaultTags.IsNull() {
+		var defaultTagsConfig providerDefaultTagsModel
+		diags = config.DefaultTags.As(ctx, &defaultTagsConfig, basetypes.ObjectAsOptions{})
+		response.Diagnostics.Append(diags...)
+		diags = defaultTagsConfig.Tags.ElementsAs(ctx, &defaultTags, false)
+		response.Diagnostics.Append(diags...)
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	// build cloudsigma sdk client
 	var creds cloudsigma.CredentialsProvider
 	if token != "" {
@@ -180,7 +230,11 @@ func (p *cloudSigmaProvider) Configure(ctx context.Context, request provider.Con
 	)
 
 	response.DataSourceData = client
-	response.ResourceData = client
+	response.ResourceData = &providerResourceData{
+		client:         client,
+		defaultTags:    defaultTags,
+		ignoreMetaKeys: ignoreMetaKeys,
+	}
 }
 
 func (p *cloudSigmaProvider) DataSources(_ context.Context) []func() datasource.DataSource {
@@ -191,6 +245,8 @@ func (p *cloudSigmaProvider) DataSources(_ context.Context) []func() datasource.
 		NewLicenseDataSource,
 		NewLocationDataSource,
 		NewProfileDataSource,
+		NewServerDataSource,
+		NewServersDataSource,
 		NewSubscriptionDataSource,
 		NewTagDataSource,
 		NewVLANDataSource,
@@ -199,8 +255,11 @@ func (p *cloudSigmaProvider) DataSources(_ context.Context) []func() datasource.
 
 func (p *cloudSigmaProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewDriveResource,
+		NewServerResource,
 		NewSnapshotResource,
 		NewSSHKeyResource,
+		NewTagMembershipResource,
 		NewTagResource,
 	}
 }
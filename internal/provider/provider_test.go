@@ -1,43 +1,26 @@
 package provider
 
 import (
-	"context"
+	"io"
+	"net/http"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
-	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/cloudsigma/terraform-provider-cloudsigma/cloudsigma"
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
 )
 
 const accTestPrefix = "tf-acc-test"
 
 var testAccProvider = New("testacc")()
 var testAccProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"cloudsigma": func() (tfprotov6.ProviderServer, error) {
-		ctx := context.Background()
-
-		upgradedSDKProvider, err := tf5to6server.UpgradeServer(ctx, cloudsigma.Provider().GRPCProvider)
-		if err != nil {
-			return nil, err
-		}
-		providers := []func() tfprotov6.ProviderServer{
-			providerserver.NewProtocol6(testAccProvider),
-			func() tfprotov6.ProviderServer { return upgradedSDKProvider },
-		}
-		muxServer, err := tf6muxserver.NewMuxServer(ctx, providers...)
-		if err != nil {
-			return nil, err
-		}
-
-		return muxServer.ProviderServer(), nil
-	},
+	"cloudsigma": providerserver.NewProtocol6WithError(testAccProvider),
 }
 
 func TestProviderConfigure_invalidCredentials(t *testing.T) {
@@ -171,3 +154,34 @@ provider "cloudsigma" {
 }
 data "cloudsigma_profile" "me" {}
 `
+
+// roundTripFunc is an http.RoundTripper answering the requests of the client
+// without network access.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// newTestClient returns a client which records the body of the last request
+// in body and answers all requests with the given JSON response.
+func newTestClient(body *string, response string) *cloudsigma.Client {
+	httpClient := &http.Client{
+		Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+			if request.Body != nil {
+				b, err := io.ReadAll(request.Body)
+				if err != nil {
+					return nil, err
+				}
+				*body = string(b)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(response)),
+				Request:    request,
+			}, nil
+		}),
+	}
+	return cloudsigma.NewClient(cloudsigma.NewTokenCredentialsProvider("token"), cloudsigma.WithHTTPClient(httpClient))
+}
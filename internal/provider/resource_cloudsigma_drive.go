@@ -0,0 +1,849 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/drive"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/server"
+)
+
+const (
+	driveDefaultCreateTimeout = 30 * time.Minute
+	driveDefaultUpdateTimeout = 30 * time.Minute
+	driveDefaultDeleteTimeout = 30 * time.Minute
+
+	// driveImportedPrivateKey marks imported drives in the private state
+	driveImportedPrivateKey = "imported"
+)
+
+var (
+	_ resource.Resource                 = (*driveResource)(nil)
+	_ resource.ResourceWithConfigure    = (*driveResource)(nil)
+	_ resource.ResourceWithImportState  = (*driveResource)(nil)
+	_ resource.ResourceWithModifyPlan   = (*driveResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*driveResource)(nil)
+)
+
+// driveResource is the drive resource implementation.
+type driveResource struct {
+	client      *cloudsigma.Client
+	defaultTags []string
+}
+
+// driveResourceModel maps the drive resource schema data.
+type driveResourceModel struct {
+	CloneDriveID    types.String   `tfsdk:"clone_drive_id"`
+	DeleteTags      types.Bool     `tfsdk:"delete_tags_on_destroy"`
+	ID              types.String   `tfsdk:"id"`
+	Media           types.String   `tfsdk:"media"`
+	MountedOn       types.List     `tfsdk:"mounted_on"`
+	Name            types.String   `tfsdk:"name"`
+	ResourceURI     types.String   `tfsdk:"resource_uri"`
+	ShutdownMethod  types.String   `tfsdk:"shutdown_method"`
+	ShutdownTimeout types.Int64    `tfsdk:"shutdown_timeout"`
+	Size            types.Int64    `tfsdk:"size"`
+	Status          types.String   `tfsdk:"status"`
+	StorageType     types.String   `tfsdk:"storage_type"`
+	TagNames        types.Set      `tfsdk:"tag_names"`
+	Tags            types.Set      `tfsdk:"tags"`
+	TagsAll         types.Set      `tfsdk:"tags_all"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	UUID            types.String   `tfsdk:"uuid"`
+}
+
+var driveMountedOnAttrTypes = map[string]attr.Type{
+	"resource_uri": types.StringType,
+	"uuid":         types.StringType,
+}
+
+func NewDriveResource() resource.Resource {
+	return &driveResource{}
+}
+
+func (r *driveResource) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cloudsigma_drive"
+}
+
+func (r *driveResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: `
+The drive resource allows you to manage CloudSigma drives.
+
+Drives can be created via cloning library drives with already installed OS.
+Use "cloudsigma_library_drive" data source to find UUID of the library drive.
+`,
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"clone_drive_id": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the drive that will be cloned. " +
+					"The value is not known for imported drives, setting it after the import does not recreate the drive.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessImported,
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+						"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+					),
+				},
+			},
+			"delete_tags_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the tags of `tag_names` are deleted on destroy if they are not applied to any other resource. " +
+					"Default `false`.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the drive.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"media": schema.StringAttribute{
+				MarkdownDescription: "Media representation type. It can be `cdrom` or `disk`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("cdrom", "disk"),
+				},
+			},
+			"mounted_on": schema.ListAttribute{
+				MarkdownDescription: "Servers on which this drive is mounted on.",
+				ElementType:         types.ObjectType{AttrTypes: driveMountedOnAttrTypes},
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Human readable name of the drive.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"resource_uri": schema.StringAttribute{
+				MarkdownDescription: "The unique resource identifier of the drive.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"shutdown_method": serverShutdownMethodSchemaAttribute(
+				"How the servers the drive is mounted on are stopped for a resize and on destroy.",
+			),
+			"shutdown_timeout": serverShutdownTimeoutSchemaAttribute(),
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the drive in bytes.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					driveSizeCanOnlyExpand(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(536870912), // 536870912 = 512MB
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The drive status.",
+				Computed:            true,
+			},
+			"storage_type": schema.StringAttribute{
+				MarkdownDescription: "Drive storage type, cannot be changed after drive creation.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					driveStorageTypeCannotChange(),
+				},
+			},
+			"tag_names": schema.SetAttribute{
+				MarkdownDescription: "A list of the tag names to be applied to the drive, in addition to `tags`. " +
+					"Tags which do not exist yet are created.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "A list of the tags UUIDs to be applied to the drive. " +
+					"Tags applied by `tag_names` and the default tags of the provider are not listed, unless they are also defined here.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags_all": schema.SetAttribute{
+				MarkdownDescription: "The UUIDs of all tags applied to the drive, " +
+					"including the tags of `tag_names` and the default tags of the provider.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the drive.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *driveResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerResourceData)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unconfigured CloudSigma client",
+			"Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+}
+
+func (r *driveResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data driveResourceModel
+
+	// read plan data into the model
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, driveDefaultCreateTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	d := cloudsigma.Drive{
+		Media:       data.Media.ValueString(),
+		Name:        data.Name.ValueString(),
+		Size:        int(data.Size.ValueInt64()),
+		StorageType: data.StorageType.ValueString(),
+	}
+
+	// clone or create drive depending on 'clone_drive_id'
+	var driveUUID string
+	if !data.CloneDriveID.IsNull() {
+		cloneRequest := &cloudsigma.DriveCloneRequest{Drive: &d}
+		tflog.Trace(ctx, "Cloning drive", map[string]any{
+			"clone_drive_uuid": data.CloneDriveID.ValueString(),
+			"payload":          cloneRequest,
+		})
+		clonedDrive, _, err := r.client.Drives.Clone(ctx, data.CloneDriveID.ValueString(), cloneRequest)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to clone drive", err.Error())
+			return
+		}
+		tflog.Trace(ctx, "Cloned drive", map[string]any{"data": clonedDrive})
+		driveUUID = clonedDrive.UUID
+	} else {
+		createRequest := &cloudsigma.DriveCreateRequest{
+			Drives: []cloudsigma.Drive{d},
+		}
+		tflog.Trace(ctx, "Creating drive", map[string]any{"payload": createRequest})
+		drives, _, err := r.client.Drives.Create(ctx, createRequest)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to create drive", err.Error())
+			return
+		}
+		tflog.Trace(ctx, "Created drive", map[string]any{"data": drives[0]})
+		driveUUID = drives[0].UUID
+	}
+
+	// save the ID as soon as possible to let Terraform track the drive if
+	// any of the following steps fails
+	diags = response.State.SetAttribute(ctx, path.Root("id"), driveUUID)
+	response.Diagnostics.Append(diags...)
+
+	tflog.Info(ctx, "Waiting for drive to be mounted or unmounted")
+	err := drive.WaitDriveStatusMountedOrUnmounted(ctx, r.client, driveUUID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Invalid drive status",
+			fmt.Sprintf("Drive status must be 'mounted' or 'unmounted': %v", err),
+		)
+		return
+	}
+
+	// attach tags if needed
+	tags, diags := expandTags(ctx, data.Tags)
+	response.Diagnostics.Append(diags...)
+	tagsByName, _, diags := expandTagNames(ctx, r.client, data.TagNames)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if tags = mergeTags(tags, tagsByName, expandDefaultTags(r.defaultTags)); len(tags) > 0 {
+		createdDrive, _, err := r.client.Drives.Get(ctx, driveUUID)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to get drive", err.Error())
+			return
+		}
+		createdDrive.Tags = tags
+
+		updateRequest := &cloudsigma.DriveUpdateRequest{Drive: createdDrive}
+		tflog.Trace(ctx, "Attaching tags to drive", map[string]any{
+			"drive_uuid": driveUUID,
+			"payload":    updateRequest,
+		})
+		_, _, err = r.client.Drives.Update(ctx, driveUUID, updateRequest)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to update drive", err.Error())
+			return
+		}
+	}
+
+	createdDrive, _, err := r.client.Drives.Get(ctx, driveUUID)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get drive", err.Error())
+		return
+	}
+
+	// map response body to attributes
+	priorTags := data.Tags
+	response.Diagnostics.Append(flattenDrive(ctx, createdDrive, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.Tags, data.TagNames, diags = readTags(ctx, r.client, createdDrive.Tags, priorTags, data.TagNames, r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *driveResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data driveResourceModel
+
+	// read state data into the model
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	driveUUID := data.ID.ValueString()
+	tflog.Trace(ctx, "Getting drive", map[string]any{"drive_uuid": driveUUID})
+	d, resp, err := r.client.Drives.Get(ctx, driveUUID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// if the drive is somehow already destroyed, mark as successfully gone
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError("Unable to get drive", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Got drive", map[string]any{"data": d})
+
+	// map response body to attributes
+	priorTags := data.Tags
+	response.Diagnostics.Append(flattenDrive(ctx, d, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.Tags, data.TagNames, diags = readTags(ctx, r.client, d.Tags, priorTags, data.TagNames, r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *driveResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data, state driveResourceModel
+
+	// read plan and state data into the models
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	diags = request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, driveDefaultUpdateTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	driveUUID := state.ID.ValueString()
+	updateRequest := &cloudsigma.Drive{
+		Media:       data.Media.ValueString(),
+		Name:        data.Name.ValueString(),
+		Size:        int(data.Size.ValueInt64()),
+		StorageType: data.StorageType.ValueString(),
+	}
+	updateRequest.Tags, diags = expandTags(ctx, data.Tags)
+	response.Diagnostics.Append(diags...)
+	tagsByName, _, diags := expandTagNames(ctx, r.client, data.TagNames)
+	response.Diagnostics.Append(diags...)
+	updateRequest.Tags = mergeTags(updateRequest.Tags, tagsByName, expandDefaultTags(r.defaultTags))
+	mountedOn, diags := expandDriveMountedOn(ctx, state.MountedOn)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// a drive can only be resized while none of the servers it is mounted on
+	// are running, only the servers stopped here are started again afterwards
+	var stoppedServers []string
+	if !data.Size.Equal(state.Size) {
+		for _, link := range mountedOn {
+			srv, _, err := r.client.Servers.Get(ctx, link.UUID)
+			if err != nil {
+				response.Diagnostics.AddError("Unable to get server", err.Error())
+				return
+			}
+			if srv.Status == serverPowerStateStopped {
+				continue
+			}
+
+			err = stopServer(ctx, r.client, link.UUID, data.ShutdownMethod, data.ShutdownTimeout)
+			if err != nil {
+				response.Diagnostics.AddError("Unable to stop server", err.Error())
+				return
+			}
+			stoppedServers = append(stoppedServers, link.UUID)
+		}
+	}
+
+	tflog.Trace(ctx, "Updating drive", map[string]any{
+		"drive_uuid": driveUUID,
+		"payload":    updateRequest,
+	})
+	// the drive is updated without the SDK, so that removing the last tag
+	// reaches the API
+	_, _, err := drive.Update(ctx, r.client, driveUUID, updateRequest)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to update drive", err.Error())
+		return
+	}
+
+	for _, serverUUID := range stoppedServers {
+		err := server.Start(ctx, r.client, serverUUID)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to start server", err.Error())
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Waiting for drive to be mounted or unmounted")
+	err = drive.WaitDriveStatusMountedOrUnmounted(ctx, r.client, driveUUID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Invalid drive status",
+			fmt.Sprintf("Drive status must be 'mounted' or 'unmounted': %v", err),
+		)
+		return
+	}
+
+	d, _, err := r.client.Drives.Get(ctx, driveUUID)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get drive", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Updated drive", map[string]any{"data": d})
+
+	// map response body to attributes
+	priorTags := data.Tags
+	response.Diagnostics.Append(flattenDrive(ctx, d, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.Tags, data.TagNames, diags = readTags(ctx, r.client, d.Tags, priorTags, data.TagNames, r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *driveResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	modifyPlanTagsAll(ctx, r.client, r.defaultTags, request, response)
+}
+
+func (r *driveResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data driveResourceModel
+
+	// read state data into the model
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, driveDefaultDeleteTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	mountedOn, diags := expandDriveMountedOn(ctx, data.MountedOn)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	for _, link := range mountedOn {
+		err := stopServer(ctx, r.client, link.UUID, data.ShutdownMethod, data.ShutdownTimeout)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to stop server", err.Error())
+			return
+		}
+	}
+
+	driveUUID := data.ID.ValueString()
+	tflog.Trace(ctx, "Deleting drive", map[string]any{"drive_uuid": driveUUID})
+	resp, err := r.client.Drives.Delete(ctx, driveUUID)
+	// handle remotely destroyed drive
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		response.Diagnostics.AddError("Unable to delete drive", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted drive", map[string]any{"drive_uuid": driveUUID})
+
+	if data.DeleteTags.ValueBool() {
+		response.Diagnostics.Append(deleteUnusedTagNames(ctx, r.client, data.TagNames)...)
+	}
+}
+
+// ImportState imports the drive by its UUID or, if there is no such drive, by
+// its name when the name is unique.
+func (r *driveResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	tflog.Trace(ctx, "Getting drive", map[string]any{"drive_uuid": request.ID})
+	_, resp, err := r.client.Drives.Get(ctx, request.ID)
+	if err == nil {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+		response.Diagnostics.Append(response.Private.SetKey(ctx, driveImportedPrivateKey, []byte("true"))...)
+		return
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		response.Diagnostics.AddError("Unable to get drive", err.Error())
+		return
+	}
+
+	opts := &cloudsigma.DriveListOptions{
+		ListOptions: cloudsigma.ListOptions{Limit: 0},
+		Names:       []string{request.ID},
+	}
+	tflog.Trace(ctx, "Getting drives", map[string]any{"opts": opts})
+	drives, _, err := r.client.Drives.List(ctx, opts)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get drives", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Got drives", map[string]any{"data": drives})
+
+	if len(drives) > 1 {
+		response.Diagnostics.AddError(
+			"Too many search results",
+			fmt.Sprintf("Drive name '%s' is not unique, please import the drive using its UUID. Found %v drives.", request.ID, len(drives)),
+		)
+		return
+	}
+	if len(drives) < 1 {
+		response.Diagnostics.AddError(
+			"No search results",
+			fmt.Sprintf("Drive with UUID or name '%s' not found.", request.ID),
+		)
+		return
+	}
+
+	diags := response.State.SetAttribute(ctx, path.Root("id"), drives[0].UUID)
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(response.Private.SetKey(ctx, driveImportedPrivateKey, []byte("true"))...)
+}
+
+func (r *driveResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 is the state written by the SDKv2 implementation of the resource
+		0: {
+			PriorSchema:   driveResourceSchemaV0(ctx),
+			StateUpgrader: upgradeDriveResourceStateV0toV1,
+		},
+	}
+}
+
+// driveResourceModelV0 maps the drive resource schema data of version 0.
+type driveResourceModelV0 struct {
+	CloneDriveID types.String   `tfsdk:"clone_drive_id"`
+	ID           types.String   `tfsdk:"id"`
+	Media        types.String   `tfsdk:"media"`
+	MountedOn    types.List     `tfsdk:"mounted_on"`
+	Name         types.String   `tfsdk:"name"`
+	ResourceURI  types.String   `tfsdk:"resource_uri"`
+	Size         types.Int64    `tfsdk:"size"`
+	Status       types.String   `tfsdk:"status"`
+	StorageType  types.String   `tfsdk:"storage_type"`
+	Tags         types.Set      `tfsdk:"tags"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+	UUID         types.String   `tfsdk:"uuid"`
+}
+
+func driveResourceSchemaV0(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"clone_drive_id": schema.StringAttribute{Optional: true},
+			"id":             schema.StringAttribute{Computed: true},
+			"media":          schema.StringAttribute{Required: true},
+			"mounted_on": schema.ListAttribute{
+				ElementType: types.ObjectType{AttrTypes: driveMountedOnAttrTypes},
+				Computed:    true,
+			},
+			"name":         schema.StringAttribute{Required: true},
+			"resource_uri": schema.StringAttribute{Computed: true},
+			"size":         schema.Int64Attribute{Required: true},
+			"status":       schema.StringAttribute{Computed: true},
+			"storage_type": schema.StringAttribute{Computed: true, Optional: true},
+			"tags":         schema.SetAttribute{ElementType: types.StringType, Computed: true, Optional: true},
+			"uuid":         schema.StringAttribute{Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// upgradeDriveResourceStateV0toV1 converts the state written by the SDKv2
+// implementation, which stores an empty string for an unset clone_drive_id.
+func upgradeDriveResourceStateV0toV1(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+	var prior driveResourceModelV0
+
+	diags := request.State.Get(ctx, &prior)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	data := driveResourceModel{
+		CloneDriveID:    stringValueOrNull(prior.CloneDriveID.ValueString()),
+		DeleteTags:      types.BoolValue(false),
+		ID:              prior.ID,
+		Media:           prior.Media,
+		MountedOn:       prior.MountedOn,
+		Name:            prior.Name,
+		ResourceURI:     prior.ResourceURI,
+		ShutdownMethod:  types.StringValue(serverShutdownMethodStop),
+		ShutdownTimeout: types.Int64Value(serverDefaultShutdownTimeout),
+		Size:            prior.Size,
+		Status:          prior.Status,
+		StorageType:     prior.StorageType,
+		TagNames:        types.SetNull(types.StringType),
+		Tags:            prior.Tags,
+		TagsAll:         prior.Tags,
+		Timeouts:        upgradeTimeoutsV0(prior.Timeouts),
+		UUID:            prior.UUID,
+	}
+	if data.MountedOn.IsNull() {
+		data.MountedOn = types.ListValueMust(types.ObjectType{AttrTypes: driveMountedOnAttrTypes}, []attr.Value{})
+	}
+	if data.Tags.IsNull() {
+		data.Tags = types.SetValueMust(types.StringType, []attr.Value{})
+		data.TagsAll = data.Tags
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func flattenDrive(ctx context.Context, d *cloudsigma.Drive, data *driveResourceModel) diag.Diagnostics {
+	var diags, diagsMountedOn, diagsTags diag.Diagnostics
+
+	data.ID = types.StringValue(d.UUID)
+	data.Media = types.StringValue(d.Media)
+	data.Name = types.StringValue(d.Name)
+	data.ResourceURI = types.StringValue(d.ResourceURI)
+	data.Size = types.Int64Value(int64(d.Size))
+	data.Status = types.StringValue(d.Status)
+	data.StorageType = types.StringValue(d.StorageType)
+	data.UUID = types.StringValue(d.UUID)
+
+	// settings which are not stored in the API use the defaults after import
+	if data.DeleteTags.IsNull() {
+		data.DeleteTags = types.BoolValue(false)
+	}
+	if data.ShutdownMethod.IsNull() {
+		data.ShutdownMethod = types.StringValue(serverShutdownMethodStop)
+	}
+	if data.ShutdownTimeout.IsNull() {
+		data.ShutdownTimeout = types.Int64Value(serverDefaultShutdownTimeout)
+	}
+
+	data.MountedOn, diagsMountedOn = flattenDriveMountedOn(d.MountedOn)
+	diags.Append(diagsMountedOn...)
+	data.Tags, diagsTags = flattenTags(ctx, d.Tags)
+	diags.Append(diagsTags...)
+	data.TagsAll, diagsTags = flattenTags(ctx, d.Tags)
+	diags.Append(diagsTags...)
+
+	return diags
+}
+
+func expandDriveMountedOn(ctx context.Context, list types.List) ([]cloudsigma.ResourceLink, diag.Diagnostics) {
+	var items []struct {
+		ResourceURI types.String `tfsdk:"resource_uri"`
+		UUID        types.String `tfsdk:"uuid"`
+	}
+	diags := list.ElementsAs(ctx, &items, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	links := make([]cloudsigma.ResourceLink, 0, len(items))
+	for _, item := range items {
+		links = append(links, cloudsigma.ResourceLink{
+			ResourceURI: item.ResourceURI.ValueString(),
+			UUID:        item.UUID.ValueString(),
+		})
+	}
+
+	return links, diags
+}
+
+func flattenDriveMountedOn(links []cloudsigma.ResourceLink) (types.List, diag.Diagnostics) {
+	items := make([]attr.Value, 0, len(links))
+	for _, link := range links {
+		items = append(items, types.ObjectValueMust(driveMountedOnAttrTypes, map[string]attr.Value{
+			"resource_uri": types.StringValue(link.ResourceURI),
+			"uuid":         types.StringValue(link.UUID),
+		}))
+	}
+
+	return types.ListValue(types.ObjectType{AttrTypes: driveMountedOnAttrTypes}, items)
+}
+
+// requiresReplaceUnlessImported requires replacement unless the source drive
+// is added to the configuration of an imported drive. The source drive of an
+// imported drive cannot be read back from the API, so adding it must not
+// recreate the drive.
+func requiresReplaceUnlessImported(ctx context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	imported, diags := request.Private.GetKey(ctx, driveImportedPrivateKey)
+	response.Diagnostics.Append(diags...)
+	response.RequiresReplace = cloneDriveIDRequiresReplace(request.StateValue, imported != nil)
+}
+
+// cloneDriveIDRequiresReplace reports whether a change of clone_drive_id
+// requires a new drive.
+func cloneDriveIDRequiresReplace(state types.String, imported bool) bool {
+	return !state.IsNull() || !imported
+}
+
+// driveSizeCanOnlyExpand returns a plan modifier that rejects a planned size
+// smaller than the current size of the drive, because drives cannot be shrunk.
+func driveSizeCanOnlyExpand() planmodifier.Int64 {
+	return driveSizeCanOnlyExpandModifier{}
+}
+
+type driveSizeCanOnlyExpandModifier struct{}
+
+func (m driveSizeCanOnlyExpandModifier) Description(_ context.Context) string {
+	return "Drive size can only be expanded."
+}
+
+func (m driveSizeCanOnlyExpandModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m driveSizeCanOnlyExpandModifier) PlanModifyInt64(_ context.Context, request planmodifier.Int64Request, response *planmodifier.Int64Response) {
+	if request.StateValue.IsNull() || request.PlanValue.IsNull() || request.PlanValue.IsUnknown() {
+		return
+	}
+
+	if request.PlanValue.ValueInt64() < request.StateValue.ValueInt64() {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid drive size",
+			fmt.Sprintf("drives `size` can only be expanded. new: %d < current: %d",
+				request.PlanValue.ValueInt64(), request.StateValue.ValueInt64()),
+		)
+	}
+}
+
+// driveStorageTypeCannotChange returns a plan modifier that rejects a change
+// of the storage type of an existing drive.
+func driveStorageTypeCannotChange() planmodifier.String {
+	return driveStorageTypeCannotChangeModifier{}
+}
+
+type driveStorageTypeCannotChangeModifier struct{}
+
+func (m driveStorageTypeCannotChangeModifier) Description(_ context.Context) string {
+	return "Drive storage type cannot be changed after creation."
+}
+
+func (m driveStorageTypeCannotChangeModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m driveStorageTypeCannotChangeModifier) PlanModifyString(_ context.Context, request planmodifier.StringRequest, response *planmodifier.StringResponse) {
+	if request.StateValue.ValueString() == "" || request.PlanValue.IsNull() || request.PlanValue.IsUnknown() {
+		return
+	}
+
+	if request.PlanValue.ValueString() != request.StateValue.ValueString() {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid drive storage type",
+			fmt.Sprintf("drives `storage_type` cannot be changed after creation. new: %s != current: %s",
+				request.PlanValue.ValueString(), request.StateValue.ValueString()),
+		)
+	}
+}
@@ -2,15 +2,535 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/drive"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/tag"
 )
 
+func init() {
+	resource.AddTestSweepers("cloudsigma_drive", &resource.Sweeper{
+		Name:         "cloudsigma_drive",
+		F:            testSweepDrives,
+		Dependencies: []string{"cloudsigma_server", "cloudsigma_snapshot"},
+	})
+}
+
+func testSweepDrives(region string) error {
+	ctx := context.Background()
+	client, err := sharedClient(region)
+	if err != nil {
+		return err
+	}
+
+	drives, _, err := client.Drives.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("getting drive list: %s", err)
+	}
+
+	for _, drive := range drives {
+		if strings.HasPrefix(drive.Name, accTestPrefix) {
+			slog.Info("Deleting cloudsigma_drive", "name", drive.Name, "uuid", drive.UUID)
+			_, err := client.Drives.Delete(ctx, drive.UUID)
+			if err != nil {
+				slog.Warn("Error deleting drive during sweep", "name", drive.Name, "error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestAccResourceCloudSigmaDrive_basic(t *testing.T) {
+	var drive cloudsigma.Drive
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	tagName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckDriveDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaDriveResource(driveName, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "media", "disk"),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "name", driveName),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "size", "5368709120"),
+					resource.TestCheckResourceAttrSet("cloudsigma_drive.test", "resource_uri"),
+					resource.TestCheckResourceAttrSet("cloudsigma_drive.test", "uuid"),
+				),
+			},
+			{
+				ResourceName:      "cloudsigma_drive.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "cloudsigma_drive.test",
+				ImportState:       true,
+				ImportStateId:     driveName,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCloudSigmaDriveResourceWithTag(tagName, driveName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "tags.#", "1"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaDriveResourceWithoutTags(driveName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaDrive_tagNames(t *testing.T) {
+	var drive cloudsigma.Drive
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	tagName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckDriveDestroy(s); err != nil {
+				return err
+			}
+			// the tag is deleted with the drive
+			client, err := sharedClient("testacc")
+			if err != nil {
+				return err
+			}
+			uuids, err := tag.Lookup(context.Background(), client, []string{tagName})
+			if err != nil {
+				return err
+			}
+			if tagUUID, ok := uuids[tagName]; ok {
+				return fmt.Errorf("tag (%s) still exists", tagUUID)
+			}
+			return nil
+		},
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaDriveResourceWithTagNames(driveName, tagName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "tag_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cloudsigma_drive.test", "tag_names.*", tagName),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "tags.#", "0"),
+					func(_ *terraform.State) error {
+						if len(drive.Tags) != 1 {
+							return fmt.Errorf("expected 1 tag on drive, got %d", len(drive.Tags))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaDrive_defaultTags(t *testing.T) {
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	tagName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	// the default tag must exist before the provider is configured
+	var tagUUID string
+	preCheck := func() {
+		testAccPreCheck(t)
+
+		client, err := sharedClient("testacc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		createRequest := &cloudsigma.TagCreateRequest{Tags: []cloudsigma.Tag{{Name: tagName}}}
+		tags, _, err := client.Tags.Create(context.Background(), createRequest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tagUUID = tags[0].UUID
+		t.Cleanup(func() {
+			_, _ = client.Tags.Delete(context.Background(), tagUUID)
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 preCheck,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckDriveDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config:          testAccCloudSigmaDriveResourceWithDefaultTags(driveName),
+				ConfigVariables: config.Variables{"default_tag": testAccDeferredStringVariable{&tagUUID}},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "tags.#", "0"),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "tags_all.#", "1"),
+					func(s *terraform.State) error {
+						return resource.TestCheckTypeSetElemAttr("cloudsigma_drive.test", "tags_all.*", tagUUID)(s)
+					},
+				),
+			},
+			{
+				Config:          testAccCloudSigmaDriveResourceWithDefaultTags(driveName),
+				ConfigVariables: config.Variables{"default_tag": testAccDeferredStringVariable{&tagUUID}},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaDrive_changeSize(t *testing.T) {
+	var drive cloudsigma.Drive
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckDriveDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaDriveResource(driveName, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "size", "5368709120"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaDriveResource(driveName, 15),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "size", "16106127360"),
+				),
+			},
+			{
+				Config:      testAccCloudSigmaDriveResource(driveName, 10),
+				ExpectError: regexp.MustCompile("drives `size` can only be expanded"),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaDrive_changeStorageType(t *testing.T) {
+	var drive cloudsigma.Drive
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckDriveDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaDriveResourceWithStorageType(driveName, "dssd"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "storage_type", "dssd"),
+				),
+			},
+			{
+				Config:      testAccCloudSigmaDriveResourceWithStorageType(driveName, "zadara"),
+				ExpectError: regexp.MustCompile("drives `storage_type` cannot be changed after creation.*"),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaDrive_emptyTag(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckDriveDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudSigmaDriveResourceWithEmptyTag(),
+				ExpectError: regexp.MustCompile(`tags.* string length must be at least 1`),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaDrive_upgradeFromSDK(t *testing.T) {
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckDriveDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"cloudsigma": {
+						VersionConstraint: "2.9.0",
+						Source:            "cloudsigma/cloudsigma",
+					},
+				},
+				Config: testAccCloudSigmaDriveResource(driveName, 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsigma_drive.test", "name", driveName),
+				),
+			},
+			{
+				ProtoV6ProviderFactories: testAccProviderFactories,
+				Config:                   testAccCloudSigmaDriveResource(driveName, 5),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestDriveResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &driveResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	var schemaResponse fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResponse)
+
+	rawState := []byte(`{
+  "clone_drive_id": "",
+  "id": "2ae05b0a-c538-47ac-ba41-e8519f782ab4",
+  "media": "disk",
+  "mounted_on": [],
+  "name": "foobar",
+  "resource_uri": "/api/2.0/drives/2ae05b0a-c538-47ac-ba41-e8519f782ab4/",
+  "size": 5368709120,
+  "status": "unmounted",
+  "storage_type": "dssd",
+  "tags": [],
+  "timeouts": null,
+  "uuid": "2ae05b0a-c538-47ac-ba41-e8519f782ab4"
+}`)
+	rawValue, err := tftypes.ValueFromJSONWithOpts(rawState, upgrader.PriorSchema.Type().TerraformType(ctx),
+		tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+	assert.NoError(t, err)
+
+	request := fwresource.UpgradeStateRequest{
+		State: &tfsdk.State{Raw: rawValue, Schema: *upgrader.PriorSchema},
+	}
+	response := fwresource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResponse.Schema},
+	}
+	upgrader.StateUpgrader(ctx, request, &response)
+	assert.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+
+	var data driveResourceModel
+	diags := response.State.Get(ctx, &data)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.True(t, data.CloneDriveID.IsNull())
+	assert.Equal(t, "2ae05b0a-c538-47ac-ba41-e8519f782ab4", data.ID.ValueString())
+	assert.Equal(t, int64(5368709120), data.Size.ValueInt64())
+	assert.Equal(t, "dssd", data.StorageType.ValueString())
+	assert.Equal(t, "stop", data.ShutdownMethod.ValueString())
+	assert.Equal(t, int64(300), data.ShutdownTimeout.ValueInt64())
+	assert.False(t, data.DeleteTags.ValueBool())
+	assert.True(t, data.TagNames.IsNull())
+	assert.Equal(t, 0, len(data.TagsAll.Elements()))
+}
+
+func TestDriveResource_updateWithoutTags(t *testing.T) {
+	var body string
+	client := newTestClient(&body, "{}")
+
+	// removing the last tag must send an empty list instead of omitting it
+	updateRequest := &cloudsigma.Drive{Name: "foobar", Tags: mergeTags(nil, nil)}
+	_, _, err := drive.Update(context.Background(), client, "drive-uuid", updateRequest)
+	assert.NoError(t, err)
+
+	var payload map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(body), &payload))
+	assert.Equal(t, []any{}, payload["tags"])
+	assert.Equal(t, "foobar", payload["name"])
+}
+
+func TestDriveResource_cloneDriveIDRequiresReplace(t *testing.T) {
+	type testCase struct {
+		state           types.String
+		imported        bool
+		expectedReplace bool
+	}
+	tests := map[string]testCase{
+		"added": {
+			state:           types.StringNull(),
+			imported:        false,
+			expectedReplace: true,
+		},
+		"added_after_import": {
+			state:           types.StringNull(),
+			imported:        true,
+			expectedReplace: false,
+		},
+		"changed": {
+			state:           types.StringValue("first-library-drive-uuid"),
+			imported:        false,
+			expectedReplace: true,
+		},
+		"changed_after_import": {
+			state:           types.StringValue("first-library-drive-uuid"),
+			imported:        true,
+			expectedReplace: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedReplace, cloneDriveIDRequiresReplace(test.state, test.imported))
+		})
+	}
+}
+
+func TestDriveResource_requiresReplaceUnlessImported(t *testing.T) {
+	// drives created without clone_drive_id have no private state
+	request := planmodifier.StringRequest{
+		Path:       path.Root("clone_drive_id"),
+		PlanValue:  types.StringValue("library-drive-uuid"),
+		StateValue: types.StringNull(),
+	}
+	response := stringplanmodifier.RequiresReplaceIfFuncResponse{}
+	requiresReplaceUnlessImported(context.Background(), request, &response)
+
+	assert.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+	assert.True(t, response.RequiresReplace)
+}
+
+func TestDriveResource_driveSizeCanOnlyExpand(t *testing.T) {
+	type testCase struct {
+		state       types.Int64
+		plan        types.Int64
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"create": {
+			state: types.Int64Null(),
+			plan:  types.Int64Value(5368709120),
+		},
+		"unchanged": {
+			state: types.Int64Value(5368709120),
+			plan:  types.Int64Value(5368709120),
+		},
+		"expand": {
+			state: types.Int64Value(5368709120),
+			plan:  types.Int64Value(16106127360),
+		},
+		"shrink": {
+			state:       types.Int64Value(16106127360),
+			plan:        types.Int64Value(5368709120),
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := planmodifier.Int64Request{
+				Path:       path.Root("size"),
+				PlanValue:  test.plan,
+				StateValue: test.state,
+			}
+			response := planmodifier.Int64Response{PlanValue: test.plan}
+			driveSizeCanOnlyExpand().PlanModifyInt64(context.Background(), request, &response)
+
+			assert.Equal(t, test.expectError, response.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestDriveResource_driveStorageTypeCannotChange(t *testing.T) {
+	type testCase struct {
+		state       types.String
+		plan        types.String
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"create": {
+			state: types.StringNull(),
+			plan:  types.StringValue("dssd"),
+		},
+		"unknown": {
+			state: types.StringValue("dssd"),
+			plan:  types.StringUnknown(),
+		},
+		"unchanged": {
+			state: types.StringValue("dssd"),
+			plan:  types.StringValue("dssd"),
+		},
+		"changed": {
+			state:       types.StringValue("dssd"),
+			plan:        types.StringValue("zadara"),
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := planmodifier.StringRequest{
+				Path:       path.Root("storage_type"),
+				PlanValue:  test.plan,
+				StateValue: test.state,
+			}
+			response := planmodifier.StringResponse{PlanValue: test.plan}
+			driveStorageTypeCannotChange().PlanModifyString(context.Background(), request, &response)
+
+			assert.Equal(t, test.expectError, response.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestDriveResource_flattenDriveMountedOn(t *testing.T) {
+	links := []cloudsigma.ResourceLink{
+		{ResourceURI: "/api/2.0/servers/first-server-uuid/", UUID: "first-server-uuid"},
+		{ResourceURI: "/api/2.0/servers/second-server-uuid/", UUID: "second-server-uuid"},
+	}
+
+	list, diags := flattenDriveMountedOn(links)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 2, len(list.Elements()))
+
+	actual, diags := expandDriveMountedOn(context.Background(), list)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, links, actual)
+}
+
 func testAccCheckDriveDestroy(s *terraform.State) error {
 	ctx := context.Background()
 	client, err := sharedClient("testacc")
@@ -58,7 +578,111 @@ func testAccCheckDriveExists(n string, drive *cloudsigma.Drive) resource.TestChe
 			return errors.New("drive not found")
 		}
 
-		drive = retrievedDrive
+		*drive = *retrievedDrive
 		return nil
 	}
 }
+
+func testAccCloudSigmaDriveResource(name string, sizeGB int) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = %d * 1024 * 1024 * 1024
+}
+`, name, sizeGB)
+}
+
+func testAccCloudSigmaDriveResourceWithTag(tagName, driveName string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_tag" "test" {
+  name = "%s"
+}
+
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = 5 * 1024 * 1024 * 1024
+
+  tags = [cloudsigma_tag.test.id]
+}
+`, tagName, driveName)
+}
+
+func testAccCloudSigmaDriveResourceWithTagNames(driveName, tagName string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = 5 * 1024 * 1024 * 1024
+
+  delete_tags_on_destroy = true
+  tag_names              = ["%s"]
+}
+`, driveName, tagName)
+}
+
+// testAccDeferredStringVariable is a configuration variable whose value is
+// only read when the test step runs, i.e. after the pre-check.
+type testAccDeferredStringVariable struct {
+	value *string
+}
+
+func (v testAccDeferredStringVariable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(*v.value)
+}
+
+func testAccCloudSigmaDriveResourceWithDefaultTags(driveName string) string {
+	return fmt.Sprintf(`
+variable "default_tag" {
+  type = string
+}
+
+provider "cloudsigma" {
+  default_tags {
+    tags = [var.default_tag]
+  }
+}
+
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = 5 * 1024 * 1024 * 1024
+}
+`, driveName)
+}
+
+func testAccCloudSigmaDriveResourceWithoutTags(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = 5 * 1024 * 1024 * 1024
+
+  tags = []
+}
+`, name)
+}
+
+func testAccCloudSigmaDriveResourceWithStorageType(name, storageType string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_drive" "test" {
+  media        = "disk"
+  name         = "%s"
+  size         = 5 * 1024 * 1024 * 1024
+  storage_type = "%s"
+}
+`, name, storageType)
+}
+
+func testAccCloudSigmaDriveResourceWithEmptyTag() string {
+	return `
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "drive-with-invalid-empty-tag-element"
+  size  = 5 * 1024 * 1024 * 1024
+
+  tags = [""]
+}
+`
+}
@@ -0,0 +1,3275 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/drive"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/server"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/tag"
+)
+
+const (
+	serverDefaultCreateTimeout = 30 * time.Minute
+	serverDefaultUpdateTimeout = 30 * time.Minute
+	serverDefaultDeleteTimeout = 30 * time.Minute
+
+	// meta keys managed by dedicated attributes
+	serverMetaBase64Fields  = "base64_fields"
+	serverMetaNetworkConfig = "cloudinit-network-config"
+	serverMetaSSHPublicKey  = "ssh_public_key"
+	serverMetaUserData      = "cloudinit-user-data"
+
+	// the boot disk uses the device channel before the drives of the list
+	serverBootDiskDevChannel = "0:0"
+
+	serverDeleteDrivesAll   = "all"
+	serverDeleteDrivesDisks = "disks"
+	serverDeleteDrivesNone  = "none"
+
+	serverCPUTypeAMD    = "amd"
+	serverCPUTypeIntel  = "intel"
+	serverHypervisorKVM = "kvm"
+
+	serverPowerStateRunning = "running"
+	serverPowerStateStopped = "stopped"
+
+	serverShutdownMethodACPI     = "acpi"
+	serverShutdownMethodStop     = "stop"
+	serverDefaultShutdownTimeout = 300 // seconds
+)
+
+var (
+	_ resource.Resource                   = (*serverResource)(nil)
+	_ resource.ResourceWithConfigure      = (*serverResource)(nil)
+	_ resource.ResourceWithImportState    = (*serverResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*serverResource)(nil)
+	_ resource.ResourceWithUpgradeState   = (*serverResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*serverResource)(nil)
+)
+
+// serverResource is the server resource implementation.
+type serverResource struct {
+	client         *cloudsigma.Client
+	defaultTags    []string
+	ignoreMetaKeys []string
+}
+
+// serverResourceModel maps the server resource schema data.
+type serverResourceModel struct {
+	BootDisk           types.Object   `tfsdk:"boot_disk"`
+	CPU                types.Int64    `tfsdk:"cpu"`
+	CPUModel           types.String   `tfsdk:"cpu_model"`
+	CPUType            types.String   `tfsdk:"cpu_type"`
+	CPUsInsteadOfCores types.Bool     `tfsdk:"cpus_instead_of_cores"`
+	DeleteDrives       types.String   `tfsdk:"delete_drives_on_destroy"`
+	DeleteTags         types.Bool     `tfsdk:"delete_tags_on_destroy"`
+	Drives             types.List     `tfsdk:"drive"`
+	EnableNuma         types.Bool     `tfsdk:"enable_numa"`
+	EnclavePageCaches  types.List     `tfsdk:"enclave_page_caches"`
+	HVRelaxed          types.Bool     `tfsdk:"hv_relaxed"`
+	HVTSC              types.Bool     `tfsdk:"hv_tsc"`
+	Hypervisor         types.String   `tfsdk:"hypervisor"`
+	ID                 types.String   `tfsdk:"id"`
+	IgnoreMetaKeys     types.Set      `tfsdk:"ignore_meta_keys"`
+	IPv4Address        types.String   `tfsdk:"ipv4_address"`
+	IPv6Address        types.String   `tfsdk:"ipv6_address"`
+	Memory             types.Int64    `tfsdk:"memory"`
+	Meta               types.Map      `tfsdk:"meta"`
+	Name               types.String   `tfsdk:"name"`
+	Networks           types.List     `tfsdk:"network"`
+	NetworkConfig      types.String   `tfsdk:"network_config"`
+	PendingRestart     types.Bool     `tfsdk:"pending_restart"`
+	PowerState         types.String   `tfsdk:"power_state"`
+	ResourceURI        types.String   `tfsdk:"resource_uri"`
+	RuntimeNICs        types.List     `tfsdk:"runtime_nics"`
+	SMP                types.Int64    `tfsdk:"smp"`
+	ShutdownMethod     types.String   `tfsdk:"shutdown_method"`
+	ShutdownTimeout    types.Int64    `tfsdk:"shutdown_timeout"`
+	SSHKeys            types.Set      `tfsdk:"ssh_keys"`
+	Status             types.String   `tfsdk:"status"`
+	TagNames           types.Set      `tfsdk:"tag_names"`
+	Tags               types.Set      `tfsdk:"tags"`
+	TagsAll            types.Set      `tfsdk:"tags_all"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+	UserData           types.String   `tfsdk:"user_data"`
+	VNCPassword        types.String   `tfsdk:"vnc_password"`
+}
+
+// serverBootDiskModel maps the boot disk cloned for the server.
+type serverBootDiskModel struct {
+	Size               types.Int64  `tfsdk:"size"`
+	SourceLibraryDrive types.String `tfsdk:"source_library_drive"`
+	StorageType        types.String `tfsdk:"storage_type"`
+	UUID               types.String `tfsdk:"uuid"`
+}
+
+var serverBootDiskAttrTypes = map[string]attr.Type{
+	"size":                 types.Int64Type,
+	"source_library_drive": types.StringType,
+	"storage_type":         types.StringType,
+	"uuid":                 types.StringType,
+}
+
+// serverDriveModel maps the drive attached to the server.
+type serverDriveModel struct {
+	BootOrder  types.Int64  `tfsdk:"boot_order"`
+	DevChannel types.String `tfsdk:"dev_channel"`
+	Device     types.String `tfsdk:"device"`
+	UUID       types.String `tfsdk:"uuid"`
+}
+
+// serverNetworkModel maps the network interface card attached to the server.
+type serverNetworkModel struct {
+	FirewallPolicy types.String `tfsdk:"firewall_policy"`
+	IPv4Address    types.String `tfsdk:"ipv4_address"`
+	IPv6           types.Object `tfsdk:"ipv6"`
+	MAC            types.String `tfsdk:"mac"`
+	Model          types.String `tfsdk:"model"`
+	RuntimeIPv4    types.String `tfsdk:"runtime_ipv4"`
+	Type           types.String `tfsdk:"type"`
+	VLANUUID       types.String `tfsdk:"vlan_uuid"`
+}
+
+var serverDriveAttrTypes = map[string]attr.Type{
+	"boot_order":  types.Int64Type,
+	"dev_channel": types.StringType,
+	"device":      types.StringType,
+	"uuid":        types.StringType,
+}
+
+// serverNetworkIPv6Model maps the IPv6 configuration of the network
+// interface card.
+type serverNetworkIPv6Model struct {
+	Address types.String `tfsdk:"address"`
+	Type    types.String `tfsdk:"type"`
+}
+
+var serverNetworkIPv6AttrTypes = map[string]attr.Type{
+	"address": types.StringType,
+	"type":    types.StringType,
+}
+
+var serverNetworkAttrTypes = map[string]attr.Type{
+	"firewall_policy": types.StringType,
+	"ipv4_address":    types.StringType,
+	"ipv6":            types.ObjectType{AttrTypes: serverNetworkIPv6AttrTypes},
+	"mac":             types.StringType,
+	"model":           types.StringType,
+	"runtime_ipv4":    types.StringType,
+	"type":            types.StringType,
+	"vlan_uuid":       types.StringType,
+}
+
+// serverRuntimeNICModel maps the network interface card of the running server.
+type serverRuntimeNICModel struct {
+	InterfaceType types.String `tfsdk:"interface_type"`
+	IO            types.Object `tfsdk:"io"`
+	IPv4Address   types.String `tfsdk:"ipv4_address"`
+	IPv6Address   types.String `tfsdk:"ipv6_address"`
+	MAC           types.String `tfsdk:"mac"`
+	VLANUUID      types.String `tfsdk:"vlan_uuid"`
+}
+
+var serverRuntimeNICIOAttrTypes = map[string]attr.Type{
+	"bytes_recv":   types.Int64Type,
+	"bytes_sent":   types.Int64Type,
+	"packets_recv": types.Int64Type,
+	"packets_sent": types.Int64Type,
+}
+
+var serverRuntimeNICAttrTypes = map[string]attr.Type{
+	"interface_type": types.StringType,
+	"io":             types.ObjectType{AttrTypes: serverRuntimeNICIOAttrTypes},
+	"ipv4_address":   types.StringType,
+	"ipv6_address":   types.StringType,
+	"mac":            types.StringType,
+	"vlan_uuid":      types.StringType,
+}
+
+var serverNetworkMACRegexp = regexp.MustCompile(`^([0-9a-f]{2}:){5}[0-9a-f]{2}$`)
+
+func NewServerResource() resource.Resource {
+	return &serverResource{}
+}
+
+func (r *serverResource) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cloudsigma_server"
+}
+
+func (r *serverResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: `
+The server resource allows you to manage CloudSigma servers.
+`,
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"boot_disk": schema.SingleNestedAttribute{
+				MarkdownDescription: "The boot disk cloned from a library drive when the server is created. " +
+					"It is attached as the first boot device, before the drives in `drive`, and deleted together with the server. " +
+					"Adding or removing the boot disk replaces the server.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfServerBootDiskAddedOrRemoved,
+						"Adding or removing the boot disk replaces the server.",
+						"Adding or removing the boot disk replaces the server.",
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"size": schema.Int64Attribute{
+						MarkdownDescription: "The size of the boot disk in bytes, not smaller than the library drive. Defaults to the size of the library drive. " +
+							"Growing the boot disk restarts a running server, shrinking it replaces the server.",
+						Computed: true,
+						Optional: true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+							int64planmodifier.RequiresReplaceIf(
+								requiresReplaceIfServerBootDiskShrinks,
+								"Shrinking the boot disk replaces the server.",
+								"Shrinking the boot disk replaces the server.",
+							),
+						},
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"source_library_drive": schema.StringAttribute{
+						MarkdownDescription: "The UUID of the library drive the boot disk is cloned from. " +
+							"Changing it replaces the server.",
+						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"storage_type": schema.StringAttribute{
+						MarkdownDescription: "The storage type of the boot disk. Changing it replaces the server.",
+						Computed:            true,
+						Optional:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"uuid": schema.StringAttribute{
+						MarkdownDescription: "The UUID of the boot disk.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"cpu": schema.Int64Attribute{
+				MarkdownDescription: "Server's CPU Clock speed measured in MHz.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(250, 124000), // 250MHz - 100GHz
+				},
+			},
+			"cpu_model": schema.StringAttribute{
+				MarkdownDescription: "The CPU model exposed to the guest operating system, e.g. `host`.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cpu_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the host CPU the server runs on. Valid values: `amd`, `intel`. " +
+					"The `cpu`, `memory` and `smp` values are validated against the limits of the chosen host type.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(serverCPUTypeAMD, serverCPUTypeIntel),
+				},
+			},
+			"cpus_instead_of_cores": schema.BoolAttribute{
+				MarkdownDescription: "Whether the guest operating system sees `smp` CPUs with one core each " +
+					"instead of one CPU with `smp` cores. Default `false`.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"delete_drives_on_destroy": schema.StringAttribute{
+				MarkdownDescription: "Which attached drives are deleted together with the server on destroy. " +
+					"`disks` deletes all attached disks, `all` deletes all attached drives including cdroms. " +
+					"Drives attached to other servers are not deleted. " +
+					"Valid values: `none`(default), `disks`, `all`.",
+				Computed: true,
+				Optional: true,
+				Default:  stringdefault.StaticString(serverDeleteDrivesNone),
+				Validators: []validator.String{
+					stringvalidator.OneOf(serverDeleteDrivesNone, serverDeleteDrivesDisks, serverDeleteDrivesAll),
+				},
+			},
+			"delete_tags_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the tags of `tag_names` are deleted on destroy if they are not applied to any other resource. " +
+					"Default `false`.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"drive": schema.ListNestedAttribute{
+				MarkdownDescription: "Drive attached to the server. " +
+					"The server will boot from the first defined drive in this resource, which get `boot_order = 1`, unless `boot_disk` is set. " +
+					"`virtio` and `scsi` drives added to or removed from the end of the list are attached or " +
+					"detached without restarting a running server.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"boot_order": schema.Int64Attribute{
+							MarkdownDescription: "The boot order of the drive, derived from its position in the list.",
+							Computed:            true,
+							PlanModifiers: []planmodifier.Int64{
+								serverDriveBootOrderFromPosition(),
+							},
+						},
+						"dev_channel": schema.StringAttribute{
+							MarkdownDescription: "The device channel of the drive, derived from its position in the list.",
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								serverDriveDevChannelFromPosition(),
+							},
+						},
+						"device": schema.StringAttribute{
+							MarkdownDescription: "Device emulation type. Valid values: `ide`, `virtio`(default), `scsi`.",
+							Computed:            true,
+							Optional:            true,
+							Default:             stringdefault.StaticString("virtio"),
+							Validators: []validator.String{
+								stringvalidator.OneOf(serverDriveDevices...),
+							},
+						},
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "The UUID of the drive.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"enclave_page_caches": schema.ListAttribute{
+				MarkdownDescription: "SGX enclaves defined with its size in bytes.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
+			"enable_numa": schema.BoolAttribute{
+				MarkdownDescription: "Whether the NUMA topology of the host is exposed to the server. Default `false`.",
+				Computed:            true,
+				Optional:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"hv_relaxed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Hyper-V relaxed timing enlightenment is enabled, " +
+					"which improves the stability of Windows guests. Default `false`.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"hv_tsc": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Hyper-V TSC page enlightenment is enabled, " +
+					"which improves the time keeping performance of Windows guests. Default `false`.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"hypervisor": schema.StringAttribute{
+				MarkdownDescription: "The hypervisor the server runs on. Valid values: `kvm`.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(serverHypervisorKVM),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the server.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ignore_meta_keys": schema.SetAttribute{
+				MarkdownDescription: "Meta keys which are ignored, in addition to the `ignore_meta_keys` of the provider, " +
+					"e.g. keys managed by agents inside the guest. Ignored keys are not reported in `meta` and their values are kept on update.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"ipv4_address": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 address.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv6_address": schema.StringAttribute{
+				MarkdownDescription: "The IPv6 address.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"memory": schema.Int64Attribute{
+				MarkdownDescription: "Server's RAM measured in bytes.",
+				Required:            true,
+			},
+			"meta": schema.MapAttribute{
+				MarkdownDescription: "The field can be used to store arbitrary information in key-value form. " +
+					"Keys which are not defined are removed from the server, unless they are listed in `ignore_meta_keys`. " +
+					"Do not specify `ssh_public_key` in the meta, use `ssh_keys` attribute instead. " +
+					"Specifying `cloudinit-user-data`, `cloudinit-network-config` and `base64_fields` in the meta is deprecated, " +
+					"use `user_data` and `network_config` attributes instead.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.LengthBetween(0, 32),
+						stringvalidator.NoneOf(serverMetaSSHPublicKey),
+					),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Human readable name of server.",
+				Required:            true,
+			},
+			"network": schema.ListNestedAttribute{
+				MarkdownDescription: "Network interface card attached to the server.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"firewall_policy": schema.StringAttribute{
+							MarkdownDescription: "The UUID of the firewall policy attached to the network interface card.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"ipv4_address": schema.StringAttribute{
+							MarkdownDescription: "The IP address reference. Only used with `static` type.",
+							Optional:            true,
+						},
+						"ipv6": schema.SingleNestedAttribute{
+							MarkdownDescription: "IPv6 configuration of the network interface card.",
+							Optional:            true,
+							Attributes: map[string]schema.Attribute{
+								"address": schema.StringAttribute{
+									MarkdownDescription: "The IPv6 address reference. Only used with `static` type.",
+									Optional:            true,
+								},
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Configuration type. Valid values: `dhcp`, `static`, `none`.",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf("dhcp", "static", "none"),
+									},
+								},
+							},
+						},
+						"mac": schema.StringAttribute{
+							MarkdownDescription: "The MAC address of the network interface card, in lowercase. " +
+								"Assigned by CloudSigma if not set.",
+							Computed: true,
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(serverNetworkMACRegexp, "must be a lowercase MAC address"),
+							},
+						},
+						"model": schema.StringAttribute{
+							MarkdownDescription: "The emulated network interface card model. " +
+								"Valid values: `virtio`(default), `e1000`, `rtl8139`.",
+							Computed: true,
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("virtio", "e1000", "rtl8139"),
+							},
+						},
+						"runtime_ipv4": schema.StringAttribute{
+							MarkdownDescription: "The IPv4 address assigned to the network interface card of the running server.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Configuration type. Valid values: `dhcp`, `static`, `manual`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("dhcp", "static", "manual"),
+							},
+						},
+						"vlan_uuid": schema.StringAttribute{
+							MarkdownDescription: "The UUID of the VLAN reference.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"network_config": schema.StringAttribute{
+				MarkdownDescription: "The cloud-init network configuration of the server. " +
+					"It is stored base64-encoded in the `cloudinit-network-config` meta field.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"pending_restart": schema.BoolAttribute{
+				MarkdownDescription: "Whether applying the planned changes restarts the running server. " +
+					"Changes of `cpu`, `memory`, `smp`, `cpu_model`, `cpu_type`, `cpus_instead_of_cores`, `enable_numa`, " +
+					"`hv_relaxed`, `hv_tsc`, `hypervisor`, `network`, growing `boot_disk` and most `drive` changes can only be applied to a stopped server. " +
+					"The planned value is kept in the state after apply and reset to `false` on the next refresh.",
+				Computed: true,
+			},
+			"power_state": schema.StringAttribute{
+				MarkdownDescription: "The desired power state of the server. Valid values: `running`(default), `stopped`.",
+				Computed:            true,
+				Optional:            true,
+				Default:             stringdefault.StaticString(serverPowerStateRunning),
+				Validators: []validator.String{
+					stringvalidator.OneOf(serverPowerStateRunning, serverPowerStateStopped),
+				},
+			},
+			"resource_uri": schema.StringAttribute{
+				MarkdownDescription: "The unique resource identifier of the server.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"runtime_nics": schema.ListNestedAttribute{
+				MarkdownDescription: "Network interface cards of the running server. Empty if the server is not running.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"interface_type": schema.StringAttribute{
+							MarkdownDescription: "The interface type, `public` or `private`.",
+							Computed:            true,
+						},
+						"io": schema.SingleNestedAttribute{
+							MarkdownDescription: "The IO statistics of the network interface card.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"bytes_recv": schema.Int64Attribute{
+									MarkdownDescription: "Received bytes.",
+									Computed:            true,
+								},
+								"bytes_sent": schema.Int64Attribute{
+									MarkdownDescription: "Sent bytes.",
+									Computed:            true,
+								},
+								"packets_recv": schema.Int64Attribute{
+									MarkdownDescription: "Received packets.",
+									Computed:            true,
+								},
+								"packets_sent": schema.Int64Attribute{
+									MarkdownDescription: "Sent packets.",
+									Computed:            true,
+								},
+							},
+						},
+						"ipv4_address": schema.StringAttribute{
+							MarkdownDescription: "The IPv4 address.",
+							Computed:            true,
+						},
+						"ipv6_address": schema.StringAttribute{
+							MarkdownDescription: "The IPv6 address.",
+							Computed:            true,
+						},
+						"mac": schema.StringAttribute{
+							MarkdownDescription: "The MAC address.",
+							Computed:            true,
+						},
+						"vlan_uuid": schema.StringAttribute{
+							MarkdownDescription: "The UUID of the VLAN.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"shutdown_method": serverShutdownMethodSchemaAttribute(
+				"How the server is stopped for changes requiring a restart and on destroy.",
+			),
+			"shutdown_timeout": serverShutdownTimeoutSchemaAttribute(),
+			"smp": schema.Int64Attribute{
+				MarkdownDescription: "Symmetric Multiprocessing (SMP) i.e. number of CPU cores.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"ssh_keys": schema.SetAttribute{
+				MarkdownDescription: "A list of the SSH key UUIDs to be applied to the server.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The current status of the server.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					serverStatusFromPowerState(),
+				},
+			},
+			"tag_names": schema.SetAttribute{
+				MarkdownDescription: "A list of the tag names to be applied to the server, in addition to `tags`. " +
+					"Tags which do not exist yet are created.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "A list of the tags UUIDs to be applied to the server. " +
+					"Tags applied by `tag_names` and the default tags of the provider are not listed, unless they are also defined here.",
+				ElementType: types.StringType,
+				Computed:    true,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags_all": schema.SetAttribute{
+				MarkdownDescription: "The UUIDs of all tags applied to the server, " +
+					"including the tags of `tag_names` and the default tags of the provider.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"user_data": schema.StringAttribute{
+				MarkdownDescription: "The cloud-init user data of the server. " +
+					"It is stored base64-encoded in the `cloudinit-user-data` meta field.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"vnc_password": schema.StringAttribute{
+				MarkdownDescription: "VNC Password to connect to server.",
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *serverResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerResourceData)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unconfigured CloudSigma client",
+			"Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+	r.ignoreMetaKeys = data.ignoreMetaKeys
+}
+
+func (r *serverResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data serverResourceModel
+
+	// read plan data into the model
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, serverDefaultCreateTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	srv := cloudsigma.Server{
+		CPU:         int(data.CPU.ValueInt64()),
+		Memory:      int(data.Memory.ValueInt64()),
+		Name:        data.Name.ValueString(),
+		SMP:         int(data.SMP.ValueInt64()),
+		VNCPassword: data.VNCPassword.ValueString(),
+	}
+
+	srv.EnclavePageCaches, diags = expandEnclavePageCaches(ctx, data.EnclavePageCaches)
+	response.Diagnostics.Append(diags...)
+	if !data.Networks.IsNull() && !data.Networks.IsUnknown() {
+		srv.NICs, diags = expandServerNICs(ctx, data.Networks)
+		response.Diagnostics.Append(diags...)
+	} else {
+		srv.NICs = []cloudsigma.ServerNIC{{
+			IP4Configuration: &cloudsigma.ServerIPConfiguration{Type: "dhcp"},
+			Model:            "virtio",
+		}}
+	}
+	srv.PublicKeys, diags = expandSSHKeys(ctx, data.SSHKeys)
+	response.Diagnostics.Append(diags...)
+	srv.Tags, diags = expandTags(ctx, data.Tags)
+	response.Diagnostics.Append(diags...)
+	srv.Meta, diags = expandServerMeta(ctx, data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	// the tags created for tag_names are deleted again if the server is not
+	// created, they are not tracked anywhere else
+	tagsByName, createdTagUUIDs, diags := expandTagNames(ctx, r.client, data.TagNames)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	srv.Tags = mergeTags(srv.Tags, tagsByName, expandDefaultTags(r.defaultTags))
+
+	// the boot disk is cloned first, it is attached together with the drives
+	if !data.BootDisk.IsNull() {
+		var bootDisk serverBootDiskModel
+		diags = data.BootDisk.As(ctx, &bootDisk, basetypes.ObjectAsOptions{})
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		bootDiskUUID, err := cloneServerBootDisk(ctx, r.client, data.Name.ValueString(), bootDisk)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to clone boot disk", err.Error())
+			deleteServerBootDiskAfterError(ctx, r.client, bootDiskUUID)
+			deleteServerTagsAfterError(ctx, r.client, createdTagUUIDs)
+			return
+		}
+		clonedDrive, _, err := r.client.Drives.Get(ctx, bootDiskUUID)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to get boot disk", err.Error())
+			deleteServerBootDiskAfterError(ctx, r.client, bootDiskUUID)
+			deleteServerTagsAfterError(ctx, r.client, createdTagUUIDs)
+			return
+		}
+		data.BootDisk = flattenServerBootDisk(clonedDrive, data.BootDisk)
+	}
+
+	// the drives are attached by the create request, so that a server is
+	// never created without its drives
+	srv.Drives, diags = expandServerDrives(ctx, data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		deleteServerBootDiskAfterError(ctx, r.client, serverBootDiskUUID(data.BootDisk))
+		deleteServerTagsAfterError(ctx, r.client, createdTagUUIDs)
+		return
+	}
+
+	createRequest := expandServerDefinition(&srv, data)
+	tflog.Trace(ctx, "Creating server", map[string]any{"payload": createRequest})
+	createdServer, _, err := server.Create(ctx, r.client, createRequest)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to create server", err.Error())
+		deleteServerBootDiskAfterError(ctx, r.client, serverBootDiskUUID(data.BootDisk))
+		deleteServerTagsAfterError(ctx, r.client, createdTagUUIDs)
+		return
+	}
+	tflog.Trace(ctx, "Created server", map[string]any{"data": createdServer})
+
+	// store the resulting UUID and the boot disk so the server is tracked and
+	// the boot disk is deleted with it even if further steps fail, Terraform
+	// then marks the server as tainted and replaces it on the next apply
+	serverUUID := createdServer.UUID
+	diags = response.State.SetAttribute(ctx, path.Root("id"), serverUUID)
+	response.Diagnostics.Append(diags...)
+	diags = response.State.SetAttribute(ctx, path.Root("boot_disk"), data.BootDisk)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if data.PowerState.ValueString() == serverPowerStateRunning {
+		err = server.Start(ctx, r.client, serverUUID)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to start server", err.Error())
+
+			// roll back the server which does not start, it stays tainted in
+			// the state if it cannot be deleted
+			err = rollbackServerCreate(ctx, r.client, serverUUID, serverBootDiskUUID(data.BootDisk))
+			if err != nil {
+				response.Diagnostics.AddWarning("Unable to roll back server creation",
+					fmt.Sprintf("The server %s is marked as tainted and will be replaced on the next apply: %s", serverUUID, err))
+				return
+			}
+			response.State.RemoveResource(ctx)
+			deleteServerTagsAfterError(ctx, r.client, createdTagUUIDs)
+			return
+		}
+	}
+
+	srvCreated, details, _, err := server.Get(ctx, r.client, serverUUID)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get server", err.Error())
+		return
+	}
+
+	// map response body to attributes
+	ignoreMetaKeys, diags := r.serverIgnoreMetaKeys(ctx, data)
+	response.Diagnostics.Append(diags...)
+	priorTags := data.Tags
+	response.Diagnostics.Append(flattenServer(ctx, srvCreated, details, ignoreMetaKeys, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.Tags, data.TagNames, diags = readTags(ctx, r.client, srvCreated.Tags, priorTags, data.TagNames, r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *serverResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data serverResourceModel
+
+	// read state data into the model
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	serverUUID := data.ID.ValueString()
+	tflog.Trace(ctx, "Getting server", map[string]any{"server_uuid": serverUUID})
+	srv, details, resp, err := server.Get(ctx, r.client, serverUUID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// if the server is somehow already destroyed, mark as successfully gone
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError("Unable to get server", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Got server", map[string]any{"data": srv})
+
+	// map response body to attributes
+	ignoreMetaKeys, diags := r.serverIgnoreMetaKeys(ctx, data)
+	response.Diagnostics.Append(diags...)
+	priorTags := data.Tags
+	response.Diagnostics.Append(flattenServer(ctx, srv, details, ignoreMetaKeys, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.Tags, data.TagNames, diags = readTags(ctx, r.client, srv.Tags, priorTags, data.TagNames, r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.BootDisk, err = readServerBootDisk(ctx, r.client, data.BootDisk)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get boot disk", err.Error())
+		return
+	}
+	// the restart announced in the plan has been applied
+	data.PendingRestart = types.BoolValue(false)
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *serverResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data, state serverResourceModel
+
+	// read plan and state data into the models
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	diags = request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, serverDefaultUpdateTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	serverUUID := state.ID.ValueString()
+	updateRequest := expandServerDefinition(&cloudsigma.Server{
+		CPU:         int(data.CPU.ValueInt64()),
+		Memory:      int(data.Memory.ValueInt64()),
+		Name:        data.Name.ValueString(),
+		SMP:         int(data.SMP.ValueInt64()),
+		VNCPassword: data.VNCPassword.ValueString(),
+	}, data)
+
+	updateRequest.EnclavePageCaches, diags = expandEnclavePageCaches(ctx, data.EnclavePageCaches)
+	response.Diagnostics.Append(diags...)
+	updateRequest.Drives, diags = expandServerDrives(ctx, data)
+	response.Diagnostics.Append(diags...)
+	bootDiskResized, diags := serverBootDiskResized(ctx, data.BootDisk, state.BootDisk)
+	response.Diagnostics.Append(diags...)
+	networksChanged, diags := serverNetworksChanged(ctx, data.Networks, state.Networks)
+	response.Diagnostics.Append(diags...)
+	if networksChanged {
+		updateRequest.NICs, diags = expandServerNICs(ctx, data.Networks)
+		response.Diagnostics.Append(diags...)
+	}
+	updateRequest.PublicKeys, diags = expandSSHKeys(ctx, data.SSHKeys)
+	response.Diagnostics.Append(diags...)
+	updateRequest.Tags, diags = expandTags(ctx, data.Tags)
+	response.Diagnostics.Append(diags...)
+	updateRequest.Meta, diags = expandServerMeta(ctx, data)
+	response.Diagnostics.Append(diags...)
+	ignoreMetaKeys, diags := r.serverIgnoreMetaKeys(ctx, data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	tagsByName, _, diags := expandTagNames(ctx, r.client, data.TagNames)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	updateRequest.Tags = mergeTags(updateRequest.Tags, tagsByName, expandDefaultTags(r.defaultTags))
+
+	// meta is replaced on update, keep the current values of the ignored keys
+	if len(ignoreMetaKeys) > 0 {
+		current, _, err := r.client.Servers.Get(ctx, serverUUID)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to get server", err.Error())
+			return
+		}
+		keepServerMetaKeys(updateRequest.Meta, current.Meta, ignoreMetaKeys)
+	}
+
+	// Note that if a server is running, only name, meta, tags and hot-pluggable
+	// drives can be changed and all other changes to the definition of a running
+	// server will be ignored.
+	needRestart, diags := serverNeedsRestart(ctx, data, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if needRestart {
+		err := stopServer(ctx, r.client, serverUUID, data.ShutdownMethod, data.ShutdownTimeout)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to stop server", err.Error())
+			return
+		}
+	}
+
+	// the boot disk can only be resized while the server is stopped
+	if bootDiskResized {
+		var bootDisk serverBootDiskModel
+		diags = data.BootDisk.As(ctx, &bootDisk, basetypes.ObjectAsOptions{})
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		err := resizeServerBootDisk(ctx, r.client, serverBootDiskUUID(state.BootDisk), bootDisk.Size.ValueInt64())
+		if err != nil {
+			response.Diagnostics.AddError("Unable to resize boot disk", err.Error())
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "Updating server", map[string]any{
+		"payload":     updateRequest,
+		"server_uuid": serverUUID,
+	})
+	_, err := server.Update(ctx, r.client, serverUUID, updateRequest)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to update server", err.Error())
+		return
+	}
+
+	// converge to the desired power state, this also starts the server again
+	// if it was stopped to apply the changes
+	err = setServerPowerState(ctx, r.client, serverUUID, data)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to change server power state", err.Error())
+		return
+	}
+
+	srv, details, _, err := server.Get(ctx, r.client, serverUUID)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get server", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Updated server", map[string]any{"data": srv})
+
+	// map response body to attributes
+	priorTags := data.Tags
+	response.Diagnostics.Append(flattenServer(ctx, srv, details, ignoreMetaKeys, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.Tags, data.TagNames, diags = readTags(ctx, r.client, srv.Tags, priorTags, data.TagNames, r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.BootDisk, err = readServerBootDisk(ctx, r.client, data.BootDisk)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get boot disk", err.Error())
+		return
+	}
+	// pending_restart keeps the planned value, Terraform rejects an applied
+	// value which differs from a known planned value. Read resets it.
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *serverResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data serverResourceModel
+
+	// read state data into the model
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, serverDefaultDeleteTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	serverUUID := data.ID.ValueString()
+	err := stopServer(ctx, r.client, serverUUID, data.ShutdownMethod, data.ShutdownTimeout)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to stop server", err.Error())
+		return
+	}
+
+	recurse := serverDeleteRecurse(data.DeleteDrives.ValueString())
+	tflog.Trace(ctx, "Deleting server", map[string]any{"recurse": recurse, "server_uuid": serverUUID})
+	resp, err := server.Delete(ctx, r.client, serverUUID, recurse)
+	// handle remotely destroyed server
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		response.Diagnostics.AddError("Unable to delete server", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted server", map[string]any{"server_uuid": serverUUID})
+
+	if bootDiskUUID := serverBootDiskUUID(data.BootDisk); bootDiskUUID != "" {
+		tflog.Trace(ctx, "Deleting boot disk", map[string]any{"drive_uuid": bootDiskUUID})
+		err = deleteServerBootDisk(ctx, r.client, bootDiskUUID)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to delete boot disk", err.Error())
+			return
+		}
+		tflog.Trace(ctx, "Deleted boot disk", map[string]any{"drive_uuid": bootDiskUUID})
+	}
+
+	if data.DeleteTags.ValueBool() {
+		response.Diagnostics.Append(deleteUnusedTagNames(ctx, r.client, data.TagNames)...)
+	}
+}
+
+func (r *serverResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data serverResourceModel
+
+	// read config data into the model
+	diags := request.Config.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(validateServerSMP(data)...)
+	response.Diagnostics.Append(validateServerMetaCloudInitKeys(ctx, data)...)
+	response.Diagnostics.Append(validateServerNetworks(ctx, data.Networks)...)
+	response.Diagnostics.Append(validateServerDrives(ctx, data.Drives)...)
+}
+
+func (r *serverResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	tagsAllChanged := modifyPlanTagsAll(ctx, r.client, r.defaultTags, request, response)
+	if request.Plan.Raw.IsNull() || response.Diagnostics.HasError() {
+		return
+	}
+
+	var data serverResourceModel
+	diags := response.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// keep the prior pending_restart value if nothing changes
+	isCreate := request.State.Raw.IsNull()
+	if !isCreate && !tagsAllChanged && request.Plan.Raw.Equal(request.State.Raw) {
+		return
+	}
+
+	ignoreMetaKeys, diags := r.serverIgnoreMetaKeys(ctx, data)
+	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(validateServerMetaKeys(ctx, data.Meta, ignoreMetaKeys)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// the client is not configured yet if the provider configuration is unknown
+	if r.client != nil && (isKnownString(data.CPUType) || isKnownString(data.Hypervisor)) {
+		capabilities, _, err := r.client.Capabilities.Get(ctx)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to get capabilities", err.Error())
+			return
+		}
+		response.Diagnostics.Append(validateServerCapabilities(capabilities, data)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// the boot disk is cloned when its UUID is not known yet
+	if r.client != nil && !data.BootDisk.IsNull() && !data.BootDisk.IsUnknown() {
+		var bootDisk serverBootDiskModel
+		diags = data.BootDisk.As(ctx, &bootDisk, basetypes.ObjectAsOptions{})
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		if bootDisk.UUID.IsUnknown() && isKnownString(bootDisk.SourceLibraryDrive) &&
+			!bootDisk.Size.IsNull() && !bootDisk.Size.IsUnknown() {
+			libraryDrive, _, err := r.client.LibraryDrives.Get(ctx, bootDisk.SourceLibraryDrive.ValueString())
+			if err != nil {
+				response.Diagnostics.AddError("Unable to get library drive", err.Error())
+				return
+			}
+			response.Diagnostics.Append(validateServerBootDiskSize(bootDisk, libraryDrive)...)
+			if response.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	// a new server is started after it is fully configured
+	if isCreate {
+		data.PendingRestart = types.BoolValue(false)
+		response.Diagnostics.Append(response.Plan.Set(ctx, &data)...)
+		return
+	}
+
+	var state serverResourceModel
+	diags = request.State.Get(ctx, &state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	needRestart, diags := serverNeedsRestart(ctx, data, state)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// keep the identity of the network interface cards, the runtime addresses
+	// stay the same unless the server is stopped or restarted
+	keepRuntime := !needRestart && data.PowerState.Equal(state.PowerState)
+	data.Networks, diags = planServerNetworks(ctx, data.Networks, state.Networks, keepRuntime)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if !keepRuntime {
+		data.IPv4Address = types.StringUnknown()
+		data.IPv6Address = types.StringUnknown()
+	}
+
+	// a stopped server or a server being stopped is not restarted
+	pendingRestart := needRestart &&
+		state.PowerState.ValueString() == serverPowerStateRunning &&
+		data.PowerState.ValueString() == serverPowerStateRunning
+	if pendingRestart {
+		response.Diagnostics.AddWarning(
+			"Server will be restarted",
+			fmt.Sprintf("Applying the planned changes to server %q requires a restart. "+
+				"The server is stopped using the %q shutdown method, updated and started again.",
+				state.Name.ValueString(), data.ShutdownMethod.ValueString()),
+		)
+	}
+
+	data.PendingRestart = types.BoolValue(pendingRestart)
+	response.Diagnostics.Append(response.Plan.Set(ctx, &data)...)
+}
+
+func (r *serverResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+}
+
+func (r *serverResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 is the state written by the SDKv2 implementation of the resource
+		0: {
+			PriorSchema:   serverResourceSchemaV0(ctx),
+			StateUpgrader: upgradeServerResourceStateV0toV1,
+		},
+	}
+}
+
+// serverResourceModelV0 maps the server resource schema data of version 0.
+type serverResourceModelV0 struct {
+	CPU               types.Int64    `tfsdk:"cpu"`
+	Drives            types.List     `tfsdk:"drive"`
+	EnclavePageCaches types.List     `tfsdk:"enclave_page_caches"`
+	ID                types.String   `tfsdk:"id"`
+	IPv4Address       types.String   `tfsdk:"ipv4_address"`
+	Memory            types.Int64    `tfsdk:"memory"`
+	Meta              types.Map      `tfsdk:"meta"`
+	Name              types.String   `tfsdk:"name"`
+	Networks          types.List     `tfsdk:"network"`
+	ResourceURI       types.String   `tfsdk:"resource_uri"`
+	SMP               types.Int64    `tfsdk:"smp"`
+	SSHKeys           types.Set      `tfsdk:"ssh_keys"`
+	Tags              types.Set      `tfsdk:"tags"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+	VNCPassword       types.String   `tfsdk:"vnc_password"`
+}
+
+// serverNetworkModelV0 maps the network interface card of version 0.
+type serverNetworkModelV0 struct {
+	IPv4Address types.String `tfsdk:"ipv4_address"`
+	Type        types.String `tfsdk:"type"`
+	VLANUUID    types.String `tfsdk:"vlan_uuid"`
+}
+
+func serverResourceSchemaV0(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"cpu": schema.Int64Attribute{Required: true},
+			"drive": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device": schema.StringAttribute{Optional: true},
+						"uuid":   schema.StringAttribute{Required: true},
+					},
+				},
+			},
+			"enclave_page_caches": schema.ListAttribute{ElementType: types.Int64Type, Optional: true},
+			"id":                  schema.StringAttribute{Computed: true},
+			"ipv4_address":        schema.StringAttribute{Computed: true},
+			"memory":              schema.Int64Attribute{Required: true},
+			"meta":                schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"name":                schema.StringAttribute{Required: true},
+			"network": schema.ListNestedAttribute{
+				Computed: true,
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ipv4_address": schema.StringAttribute{Optional: true},
+						"type":         schema.StringAttribute{Optional: true},
+						"vlan_uuid":    schema.StringAttribute{Optional: true},
+					},
+				},
+			},
+			"resource_uri": schema.StringAttribute{Computed: true},
+			"smp":          schema.Int64Attribute{Computed: true, Optional: true},
+			"ssh_keys":     schema.SetAttribute{ElementType: types.StringType, Optional: true},
+			"tags":         schema.SetAttribute{ElementType: types.StringType, Computed: true, Optional: true},
+			"vnc_password": schema.StringAttribute{Required: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// upgradeServerResourceStateV0toV1 converts the state written by the SDKv2
+// implementation, which stores empty strings and empty collections instead
+// of null values for unset attributes.
+func upgradeServerResourceStateV0toV1(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+	var prior serverResourceModelV0
+
+	diags := request.State.Get(ctx, &prior)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	data := serverResourceModel{
+		BootDisk:           types.ObjectNull(serverBootDiskAttrTypes),
+		CPU:                prior.CPU,
+		CPUModel:           types.StringNull(),
+		CPUType:            types.StringNull(),
+		CPUsInsteadOfCores: types.BoolValue(false),
+		DeleteDrives:       types.StringValue(serverDeleteDrivesNone),
+		DeleteTags:         types.BoolValue(false),
+		Drives:             prior.Drives,
+		EnableNuma:         types.BoolValue(false),
+		EnclavePageCaches:  prior.EnclavePageCaches,
+		HVRelaxed:          types.BoolValue(false),
+		HVTSC:              types.BoolValue(false),
+		Hypervisor:         types.StringNull(),
+		ID:                 prior.ID,
+		IgnoreMetaKeys:     types.SetNull(types.StringType),
+		IPv4Address:        prior.IPv4Address,
+		IPv6Address:        types.StringNull(),
+		Memory:             prior.Memory,
+		Meta:               prior.Meta,
+		Name:               prior.Name,
+		NetworkConfig:      types.StringNull(),
+		PendingRestart:     types.BoolValue(false),
+		PowerState:         types.StringValue(serverPowerStateRunning),
+		ResourceURI:        prior.ResourceURI,
+		RuntimeNICs:        types.ListNull(types.ObjectType{AttrTypes: serverRuntimeNICAttrTypes}),
+		SMP:                prior.SMP,
+		ShutdownMethod:     types.StringValue(serverShutdownMethodStop),
+		ShutdownTimeout:    types.Int64Value(serverDefaultShutdownTimeout),
+		SSHKeys:            prior.SSHKeys,
+		Status:             types.StringNull(),
+		TagNames:           types.SetNull(types.StringType),
+		Tags:               prior.Tags,
+		TagsAll:            prior.Tags,
+		Timeouts:           upgradeTimeoutsV0(prior.Timeouts),
+		UserData:           types.StringNull(),
+		VNCPassword:        prior.VNCPassword,
+	}
+
+	if len(prior.Drives.Elements()) == 0 {
+		data.Drives = types.ListNull(types.ObjectType{AttrTypes: serverDriveAttrTypes})
+	} else {
+		var priorDrives []struct {
+			Device types.String `tfsdk:"device"`
+			UUID   types.String `tfsdk:"uuid"`
+		}
+		response.Diagnostics.Append(prior.Drives.ElementsAs(ctx, &priorDrives, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		drives := make([]serverDriveModel, 0, len(priorDrives))
+		for i, drive := range priorDrives {
+			drives = append(drives, serverDriveModel{
+				BootOrder:  types.Int64Value(int64(serverDriveBootOrder(i))),
+				DevChannel: types.StringValue(serverDriveDevChannel(i)),
+				Device:     drive.Device,
+				UUID:       drive.UUID,
+			})
+		}
+		data.Drives, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: serverDriveAttrTypes}, drives)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+	if len(prior.EnclavePageCaches.Elements()) == 0 {
+		data.EnclavePageCaches = types.ListNull(types.Int64Type)
+	}
+	if len(prior.Meta.Elements()) == 0 {
+		data.Meta = types.MapNull(types.StringType)
+	}
+	if len(prior.SSHKeys.Elements()) == 0 {
+		data.SSHKeys = types.SetNull(types.StringType)
+	}
+	if prior.Tags.IsNull() {
+		data.Tags = types.SetValueMust(types.StringType, []attr.Value{})
+		data.TagsAll = data.Tags
+	}
+
+	if prior.Networks.IsNull() {
+		data.Networks = types.ListNull(types.ObjectType{AttrTypes: serverNetworkAttrTypes})
+	} else {
+		var priorNetworks []serverNetworkModelV0
+		response.Diagnostics.Append(prior.Networks.ElementsAs(ctx, &priorNetworks, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		networks := make([]serverNetworkModel, 0, len(priorNetworks))
+		for _, network := range priorNetworks {
+			networks = append(networks, serverNetworkModel{
+				FirewallPolicy: types.StringNull(),
+				IPv4Address:    stringValueOrNull(network.IPv4Address.ValueString()),
+				IPv6:           types.ObjectNull(serverNetworkIPv6AttrTypes),
+				MAC:            types.StringNull(),
+				Model:          types.StringNull(),
+				RuntimeIPv4:    types.StringNull(),
+				Type:           stringValueOrNull(network.Type.ValueString()),
+				VLANUUID:       stringValueOrNull(network.VLANUUID.ValueString()),
+			})
+		}
+		data.Networks, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: serverNetworkAttrTypes}, networks)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+// flattenServer maps the API representation of the server to the resource
+// model. Optional collections that are null in the model and empty in the
+// API stay null to keep the state consistent with the configuration.
+func flattenServer(ctx context.Context, srv *cloudsigma.Server, details *server.Details, ignoreMetaKeys []string, data *serverResourceModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	if details == nil {
+		details = &server.Details{}
+	}
+	runtime := details.Runtime
+
+	data.CPU = types.Int64Value(int64(srv.CPU))
+	data.CPUModel = stringValueOrNull(details.CPUModel)
+	data.CPUType = stringValueOrNull(srv.CPUType)
+	data.CPUsInsteadOfCores = types.BoolValue(srv.CPUsInsteadOfCores)
+	data.EnableNuma = types.BoolValue(srv.EnableNuma)
+	data.HVRelaxed = types.BoolValue(details.HVRelaxed)
+	data.HVTSC = types.BoolValue(details.HVTSC)
+	data.Hypervisor = stringValueOrNull(srv.Hypervisor)
+	data.ID = types.StringValue(srv.UUID)
+	data.IPv4Address = types.StringValue(findIPv4Address(srv, "public"))
+	data.IPv6Address = types.StringValue(findIPv6Address(srv, "public"))
+	data.Memory = types.Int64Value(int64(srv.Memory))
+	data.Name = types.StringValue(srv.Name)
+	data.ResourceURI = types.StringValue(srv.ResourceURI)
+	data.SMP = types.Int64Value(int64(srv.SMP))
+	data.Status = types.StringValue(srv.Status)
+	data.VNCPassword = types.StringValue(srv.VNCPassword)
+
+	// destroy and shutdown settings are not stored in the API, use the defaults
+	// after import
+	if data.DeleteDrives.IsNull() {
+		data.DeleteDrives = types.StringValue(serverDeleteDrivesNone)
+	}
+	if data.DeleteTags.IsNull() {
+		data.DeleteTags = types.BoolValue(false)
+	}
+	if data.ShutdownMethod.IsNull() {
+		data.ShutdownMethod = types.StringValue(serverShutdownMethodStop)
+	}
+	if data.ShutdownTimeout.IsNull() {
+		data.ShutdownTimeout = types.Int64Value(serverDefaultShutdownTimeout)
+	}
+
+	// keep the desired power state while the server is in a transitional state
+	if srv.Status == serverPowerStateRunning || srv.Status == serverPowerStateStopped {
+		data.PowerState = types.StringValue(srv.Status)
+	} else if data.PowerState.IsNull() {
+		data.PowerState = types.StringValue(serverPowerStateRunning)
+	}
+
+	// the boot disk is not part of the drive list
+	serverDrives := make([]cloudsigma.ServerDrive, 0, len(srv.Drives))
+	bootDiskUUID := serverBootDiskUUID(data.BootDisk)
+	for _, serverDrive := range srv.Drives {
+		if bootDiskUUID != "" && serverDrive.Drive != nil && serverDrive.Drive.UUID == bootDiskUUID {
+			continue
+		}
+		serverDrives = append(serverDrives, serverDrive)
+	}
+	if len(serverDrives) > 0 || !data.Drives.IsNull() {
+		data.Drives, d = flattenServerDrives(ctx, serverDrives)
+		diags.Append(d...)
+	}
+
+	if len(srv.EnclavePageCaches) > 0 || !data.EnclavePageCaches.IsNull() {
+		data.EnclavePageCaches, d = flattenEnclavePageCaches(ctx, srv.EnclavePageCaches)
+		diags.Append(d...)
+	}
+
+	// the cloud-init keys stay in meta while they are defined there
+	metaKeys := serverMetaKeys(data.Meta)
+	meta := flattenServerMeta(srv.Meta, ignoreMetaKeys, metaKeys)
+	if len(meta) > 0 || !data.Meta.IsNull() {
+		data.Meta, d = types.MapValueFrom(ctx, types.StringType, meta)
+		diags.Append(d...)
+	}
+
+	data.Networks, d = flattenServerNICs(ctx, srv, runtime, data.Networks)
+	diags.Append(d...)
+
+	data.RuntimeNICs, d = flattenServerRuntimeNICs(ctx, srv, runtime)
+	diags.Append(d...)
+
+	if networkConfig, ok := flattenServerMetaField(srv.Meta, serverMetaNetworkConfig); (ok && !slices.Contains(metaKeys, serverMetaNetworkConfig)) || !data.NetworkConfig.IsNull() {
+		data.NetworkConfig = types.StringValue(networkConfig)
+	}
+
+	if len(srv.PublicKeys) > 0 || !data.SSHKeys.IsNull() {
+		data.SSHKeys, d = flattenSSHKeys(ctx, srv.PublicKeys)
+		diags.Append(d...)
+	}
+
+	data.Tags, d = flattenTags(ctx, srv.Tags)
+	diags.Append(d...)
+	data.TagsAll, d = flattenTags(ctx, srv.Tags)
+	diags.Append(d...)
+
+	if userData, ok := flattenServerMetaField(srv.Meta, serverMetaUserData); (ok && !slices.Contains(metaKeys, serverMetaUserData)) || !data.UserData.IsNull() {
+		data.UserData = types.StringValue(userData)
+	}
+
+	return diags
+}
+
+// expandServerDefinition completes the server with the CPU and hypervisor
+// options which the SDK does not support or omits if they are false.
+func expandServerDefinition(srv *cloudsigma.Server, data serverResourceModel) server.Definition {
+	srv.CPUType = data.CPUType.ValueString()
+	srv.Hypervisor = data.Hypervisor.ValueString()
+
+	// drives, meta and tags are always sent, so that removing the last drive,
+	// all keys and the last tag reaches the API
+	drives := srv.Drives
+	if drives == nil {
+		drives = []cloudsigma.ServerDrive{}
+	}
+	meta := srv.Meta
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+	tags := srv.Tags
+	if tags == nil {
+		tags = []cloudsigma.Tag{}
+	}
+
+	return server.Definition{
+		Server:             srv,
+		CPUModel:           data.CPUModel.ValueString(),
+		CPUsInsteadOfCores: data.CPUsInsteadOfCores.ValueBool(),
+		Drives:             drives,
+		EnableNuma:         data.EnableNuma.ValueBool(),
+		HVRelaxed:          data.HVRelaxed.ValueBool(),
+		HVTSC:              data.HVTSC.ValueBool(),
+		Meta:               meta,
+		Tags:               tags,
+	}
+}
+
+// expandServerDrives returns the drives attached to the server, the boot disk
+// first followed by the drives of the drive list.
+func expandServerDrives(ctx context.Context, data serverResourceModel) ([]cloudsigma.ServerDrive, diag.Diagnostics) {
+	var drives []serverDriveModel
+	diags := data.Drives.ElementsAs(ctx, &drives, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	serverDrives := make([]cloudsigma.ServerDrive, 0, len(drives)+1)
+	if bootDiskUUID := serverBootDiskUUID(data.BootDisk); bootDiskUUID != "" {
+		serverDrives = append(serverDrives, cloudsigma.ServerDrive{
+			BootOrder:  serverDriveBootOrder(0),
+			DevChannel: serverBootDiskDevChannel,
+			Device:     "virtio",
+			Drive:      &cloudsigma.Drive{UUID: bootDiskUUID},
+		})
+	}
+	offset := len(serverDrives)
+	for i, drive := range drives {
+		serverDrives = append(serverDrives, cloudsigma.ServerDrive{
+			BootOrder:  serverDriveBootOrder(i + offset),
+			DevChannel: serverDriveDevChannel(i),
+			Device:     drive.Device.ValueString(),
+			Drive:      &cloudsigma.Drive{UUID: drive.UUID.ValueString()},
+		})
+	}
+
+	return serverDrives, diags
+}
+
+// rollbackServerCreate deletes the server and its boot disk after the server
+// has been created but could not be started. The attached drives of the drive
+// list are kept. A new context is used, as the one of the create operation may
+// have expired.
+func rollbackServerCreate(ctx context.Context, client *cloudsigma.Client, serverUUID, bootDiskUUID string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serverDefaultDeleteTimeout)
+	defer cancel()
+
+	tflog.Trace(ctx, "Rolling back server creation", map[string]any{"server_uuid": serverUUID})
+	err := server.Stop(ctx, client, serverUUID)
+	if err != nil {
+		return err
+	}
+	resp, err := server.Delete(ctx, client, serverUUID, server.RecurseNone)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	if bootDiskUUID != "" {
+		err = deleteServerBootDisk(ctx, client, bootDiskUUID)
+		if err != nil {
+			return err
+		}
+	}
+	tflog.Trace(ctx, "Rolled back server creation", map[string]any{"server_uuid": serverUUID})
+	return nil
+}
+
+// cloneServerBootDisk clones the boot disk from the library drive and grows it
+// to the planned size. The UUID of the clone is returned even if resizing it
+// fails, so that it can be deleted.
+func cloneServerBootDisk(ctx context.Context, client *cloudsigma.Client, name string, bootDisk serverBootDiskModel) (string, error) {
+	cloneRequest := &cloudsigma.LibraryDriveCloneRequest{
+		LibraryDrive: &cloudsigma.LibraryDrive{
+			Media:       "disk",
+			Name:        name,
+			StorageType: bootDisk.StorageType.ValueString(),
+		},
+	}
+	tflog.Trace(ctx, "Cloning library drive", map[string]any{
+		"library_drive_uuid": bootDisk.SourceLibraryDrive.ValueString(),
+		"payload":            cloneRequest,
+	})
+	clonedDrive, _, err := client.LibraryDrives.Clone(ctx, bootDisk.SourceLibraryDrive.ValueString(), cloneRequest)
+	if err != nil {
+		return "", err
+	}
+	tflog.Trace(ctx, "Cloned library drive", map[string]any{"data": clonedDrive})
+
+	tflog.Info(ctx, "Waiting for drive to be mounted or unmounted")
+	err = drive.WaitDriveStatusMountedOrUnmounted(ctx, client, clonedDrive.UUID)
+	if err != nil {
+		return clonedDrive.UUID, fmt.Errorf("drive status must be 'mounted' or 'unmounted': %w", err)
+	}
+
+	if !bootDisk.Size.IsNull() && !bootDisk.Size.IsUnknown() {
+		err = resizeServerBootDisk(ctx, client, clonedDrive.UUID, bootDisk.Size.ValueInt64())
+	}
+	return clonedDrive.UUID, err
+}
+
+// resizeServerBootDisk grows the boot disk to the given size. The server must
+// be stopped. A drive cannot shrink, a smaller size is an error.
+func resizeServerBootDisk(ctx context.Context, client *cloudsigma.Client, driveUUID string, size int64) error {
+	d, _, err := client.Drives.Get(ctx, driveUUID)
+	if err != nil {
+		return err
+	}
+	if int64(d.Size) == size {
+		return nil
+	}
+	if int64(d.Size) > size {
+		return fmt.Errorf("the boot disk %s of %d bytes cannot be shrunk to %d bytes", driveUUID, d.Size, size)
+	}
+
+	updateRequest := &cloudsigma.DriveUpdateRequest{
+		Drive: &cloudsigma.Drive{
+			Media:       d.Media,
+			Name:        d.Name,
+			Size:        int(size),
+			StorageType: d.StorageType,
+		},
+	}
+	tflog.Trace(ctx, "Resizing boot disk", map[string]any{
+		"drive_uuid": driveUUID,
+		"payload":    updateRequest,
+	})
+	_, _, err = client.Drives.Update(ctx, driveUUID, updateRequest)
+	if err != nil {
+		return err
+	}
+
+	tflog.Info(ctx, "Waiting for drive to be mounted or unmounted")
+	err = drive.WaitDriveStatusMountedOrUnmounted(ctx, client, driveUUID)
+	if err != nil {
+		return fmt.Errorf("drive status must be 'mounted' or 'unmounted': %w", err)
+	}
+	return nil
+}
+
+// readServerBootDisk returns the current boot disk of the server, or null if
+// it is deleted.
+func readServerBootDisk(ctx context.Context, client *cloudsigma.Client, prior types.Object) (types.Object, error) {
+	bootDiskUUID := serverBootDiskUUID(prior)
+	if bootDiskUUID == "" {
+		return prior, nil
+	}
+
+	tflog.Trace(ctx, "Getting boot disk", map[string]any{"drive_uuid": bootDiskUUID})
+	d, resp, err := client.Drives.Get(ctx, bootDiskUUID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return types.ObjectNull(serverBootDiskAttrTypes), nil
+		}
+		return prior, err
+	}
+
+	return flattenServerBootDisk(d, prior), nil
+}
+
+// deleteServerBootDisk deletes the boot disk, if it is not deleted yet.
+func deleteServerBootDisk(ctx context.Context, client *cloudsigma.Client, driveUUID string) error {
+	resp, err := client.Drives.Delete(ctx, driveUUID)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return err
+	}
+	return nil
+}
+
+// deleteServerBootDiskAfterError deletes the boot disk cloned for a server
+// which could not be created, so that it does not remain without being tracked.
+func deleteServerBootDiskAfterError(ctx context.Context, client *cloudsigma.Client, driveUUID string) {
+	if driveUUID == "" {
+		return
+	}
+	if err := deleteServerBootDisk(ctx, client, driveUUID); err != nil {
+		tflog.Warn(ctx, "Unable to delete boot disk", map[string]any{"drive_uuid": driveUUID, "error": err.Error()})
+	}
+}
+
+// deleteServerTagsAfterError deletes the tags created for a server which is
+// not created. Errors are only logged, the error which caused the cleanup is
+// reported to the user.
+func deleteServerTagsAfterError(ctx context.Context, client *cloudsigma.Client, tagUUIDs []string) {
+	for _, tagUUID := range tagUUIDs {
+		resp, err := client.Tags.Delete(ctx, tagUUID)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			tflog.Warn(ctx, "Unable to delete tag", map[string]any{"tag_uuid": tagUUID, "error": err.Error()})
+		}
+	}
+}
+
+// serverBootDiskUUID returns the UUID of the boot disk, or an empty string if
+// the server has no boot disk or it is not cloned yet.
+func serverBootDiskUUID(object types.Object) string {
+	if object.IsNull() || object.IsUnknown() {
+		return ""
+	}
+	uuid, _ := object.Attributes()["uuid"].(types.String)
+	return uuid.ValueString()
+}
+
+// flattenServerBootDisk returns the boot disk of the server. The source library
+// drive is not stored in the API and kept from the prior value.
+func flattenServerBootDisk(bootDisk *cloudsigma.Drive, prior types.Object) types.Object {
+	if bootDisk == nil {
+		return types.ObjectNull(serverBootDiskAttrTypes)
+	}
+
+	sourceLibraryDrive := types.StringNull()
+	if !prior.IsNull() && !prior.IsUnknown() {
+		sourceLibraryDrive, _ = prior.Attributes()["source_library_drive"].(types.String)
+	}
+
+	return types.ObjectValueMust(serverBootDiskAttrTypes, map[string]attr.Value{
+		"size":                 types.Int64Value(int64(bootDisk.Size)),
+		"source_library_drive": sourceLibraryDrive,
+		"storage_type":         stringValueOrNull(bootDisk.StorageType),
+		"uuid":                 types.StringValue(bootDisk.UUID),
+	})
+}
+
+// serverBootDiskResized reports whether the boot disk is planned to grow.
+func serverBootDiskResized(ctx context.Context, plan, state types.Object) (bool, diag.Diagnostics) {
+	if plan.IsNull() || plan.IsUnknown() || state.IsNull() || state.IsUnknown() {
+		return false, nil
+	}
+
+	var planBootDisk, stateBootDisk serverBootDiskModel
+	diags := plan.As(ctx, &planBootDisk, basetypes.ObjectAsOptions{})
+	diags.Append(state.As(ctx, &stateBootDisk, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return !planBootDisk.Size.IsUnknown() && !planBootDisk.Size.Equal(stateBootDisk.Size), diags
+}
+
+// flattenServerDrives returns the attached drives ordered by their boot order,
+// drives without boot order are placed at the end of the list.
+func flattenServerDrives(ctx context.Context, serverDrives []cloudsigma.ServerDrive) (types.List, diag.Diagnostics) {
+	sorted := make([]cloudsigma.ServerDrive, len(serverDrives))
+	copy(sorted, serverDrives)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[j].BootOrder == 0 {
+			return sorted[i].BootOrder != 0
+		}
+		return sorted[i].BootOrder != 0 && sorted[i].BootOrder < sorted[j].BootOrder
+	})
+
+	drives := make([]serverDriveModel, 0, len(sorted))
+	for _, serverDrive := range sorted {
+		drive := serverDriveModel{
+			BootOrder:  types.Int64Value(int64(serverDrive.BootOrder)),
+			DevChannel: types.StringValue(serverDrive.DevChannel),
+			Device:     types.StringValue(serverDrive.Device),
+			UUID:       types.StringNull(),
+		}
+		if serverDrive.Drive != nil {
+			drive.UUID = types.StringValue(serverDrive.Drive.UUID)
+		}
+		drives = append(drives, drive)
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: serverDriveAttrTypes}, drives)
+}
+
+func expandServerNICs(ctx context.Context, list types.List) ([]cloudsigma.ServerNIC, diag.Diagnostics) {
+	var networks []serverNetworkModel
+	diags := list.ElementsAs(ctx, &networks, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	nics := make([]cloudsigma.ServerNIC, len(networks))
+	for i, network := range networks {
+		networkType := network.Type.ValueString()
+		networkAddress := network.IPv4Address.ValueString()
+		networkVLAN := network.VLANUUID.ValueString()
+
+		// addresses unknown during plan validation are checked here
+		if networkType == "static" && networkAddress == "" {
+			diags.AddAttributeError(path.Root("network").AtListIndex(i).AtName("ipv4_address"),
+				"Invalid network configuration", "network address cannot be empty if type is static")
+			return nil, diags
+		}
+
+		ip6Configuration, d := expandServerNICIPv6(ctx, network.IPv6)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		nics[i].IP6Configuration = ip6Configuration
+
+		switch {
+		case networkType == "static":
+			nics[i].IP4Configuration = &cloudsigma.ServerIPConfiguration{
+				Type:      networkType,
+				IPAddress: &cloudsigma.IP{UUID: networkAddress},
+			}
+		case networkType == "dhcp" || networkType == "manual":
+			nics[i].IP4Configuration = &cloudsigma.ServerIPConfiguration{
+				Type: networkType,
+			}
+		case networkVLAN != "":
+			nics[i].VLAN = &cloudsigma.VLAN{UUID: networkVLAN}
+		}
+
+		// keep the MAC address to not replace the network interface card
+		nics[i].MACAddress = network.MAC.ValueString()
+		nics[i].Model = network.Model.ValueString()
+		if nics[i].Model == "" {
+			nics[i].Model = "virtio"
+		}
+		if firewallPolicy := network.FirewallPolicy.ValueString(); firewallPolicy != "" {
+			nics[i].FirewallPolicy = &cloudsigma.FirewallPolicy{UUID: firewallPolicy}
+		}
+	}
+
+	return nics, diags
+}
+
+// expandServerNICIPv6 returns the IPv6 configuration of the network interface
+// card, or nil if IPv6 is not configured or its type is none.
+func expandServerNICIPv6(ctx context.Context, object types.Object) (*cloudsigma.ServerIPConfiguration, diag.Diagnostics) {
+	if object.IsNull() || object.IsUnknown() {
+		return nil, nil
+	}
+
+	var ipv6 serverNetworkIPv6Model
+	diags := object.As(ctx, &ipv6, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	ipv6Type := ipv6.Type.ValueString()
+	ipv6Address := ipv6.Address.ValueString()
+	switch ipv6Type {
+	case "static":
+		if ipv6Address == "" {
+			diags.AddError("Invalid network configuration", "ipv6 address cannot be empty if type is static")
+			return nil, diags
+		}
+		return &cloudsigma.ServerIPConfiguration{
+			Type:      ipv6Type,
+			IPAddress: &cloudsigma.IP{UUID: ipv6Address},
+		}, diags
+	case "dhcp":
+		return &cloudsigma.ServerIPConfiguration{Type: ipv6Type}, diags
+	}
+
+	return nil, diags
+}
+
+// flattenServerNICIPv6 returns the IPv6 configuration of the network interface
+// card. A missing configuration is reported as type none, if it is planned
+// that way.
+func flattenServerNICIPv6(nic cloudsigma.ServerNIC, prior types.Object) types.Object {
+	if nic.IP6Configuration == nil {
+		if priorType, ok := prior.Attributes()["type"].(types.String); ok && priorType.ValueString() == "none" {
+			return prior
+		}
+		return types.ObjectNull(serverNetworkIPv6AttrTypes)
+	}
+
+	address := types.StringNull()
+	if nic.IP6Configuration.IPAddress != nil {
+		address = stringValueOrNull(nic.IP6Configuration.IPAddress.UUID)
+	}
+	return types.ObjectValueMust(serverNetworkIPv6AttrTypes, map[string]attr.Value{
+		"address": address,
+		"type":    stringValueOrNull(nic.IP6Configuration.Type),
+	})
+}
+
+// flattenServerNICs returns the network interface cards of the server. The
+// cards are ordered like the prior ones with the same MAC address, so that
+// a different order in the API response is not reported as a change.
+func flattenServerNICs(ctx context.Context, srv *cloudsigma.Server, runtime *server.Runtime, prior types.List) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var priorNetworks []serverNetworkModel
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &priorNetworks, false)...)
+		if diags.HasError() {
+			return types.ListNull(types.ObjectType{AttrTypes: serverNetworkAttrTypes}), diags
+		}
+	}
+	positions := make(map[string]int, len(priorNetworks))
+	for i, network := range priorNetworks {
+		if !network.MAC.IsNull() && !network.MAC.IsUnknown() {
+			positions[network.MAC.ValueString()] = i
+		}
+	}
+	// findPrior returns the prior card with the same MAC address or, if the
+	// MAC address is not known yet, the prior card at the same position
+	findPrior := func(i int, mac string) *serverNetworkModel {
+		if j, ok := positions[mac]; ok {
+			return &priorNetworks[j]
+		}
+		if i < len(priorNetworks) && priorNetworks[i].MAC.IsUnknown() {
+			return &priorNetworks[i]
+		}
+		return nil
+	}
+
+	networks := make([]serverNetworkModel, 0, len(srv.NICs))
+	for i, nic := range srv.NICs {
+		priorIPv6 := types.ObjectNull(serverNetworkIPv6AttrTypes)
+		if priorNetwork := findPrior(i, nic.MACAddress); priorNetwork != nil {
+			priorIPv6 = priorNetwork.IPv6
+		}
+
+		network := serverNetworkModel{
+			FirewallPolicy: types.StringNull(),
+			IPv4Address:    types.StringNull(),
+			IPv6:           flattenServerNICIPv6(nic, priorIPv6),
+			MAC:            stringValueOrNull(nic.MACAddress),
+			Model:          stringValueOrNull(nic.Model),
+			RuntimeIPv4:    types.StringNull(),
+			Type:           types.StringNull(),
+			VLANUUID:       types.StringNull(),
+		}
+		if nic.FirewallPolicy != nil {
+			network.FirewallPolicy = stringValueOrNull(nic.FirewallPolicy.UUID)
+		}
+		if nic.IP4Configuration != nil {
+			network.Type = stringValueOrNull(nic.IP4Configuration.Type)
+			if nic.IP4Configuration.IPAddress != nil {
+				network.IPv4Address = stringValueOrNull(nic.IP4Configuration.IPAddress.UUID)
+			}
+		}
+		if nic.VLAN != nil {
+			network.VLANUUID = stringValueOrNull(nic.VLAN.UUID)
+		}
+		if runtimeNIC := findRuntimeNIC(runtime, nic.MACAddress); runtimeNIC != nil && runtimeNIC.IPv4 != nil {
+			network.RuntimeIPv4 = stringValueOrNull(runtimeNIC.IPv4.UUID)
+		}
+		networks = append(networks, network)
+	}
+
+	position := func(network serverNetworkModel) int {
+		if i, ok := positions[network.MAC.ValueString()]; ok {
+			return i
+		}
+		return len(priorNetworks)
+	}
+	sort.SliceStable(networks, func(i, j int) bool {
+		return position(networks[i]) < position(networks[j])
+	})
+
+	list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: serverNetworkAttrTypes}, networks)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// flattenServerRuntimeNICs returns the network interface cards of the running
+// server. The VLAN is taken from the configured card with the same MAC
+// address, if the runtime information does not contain it.
+func flattenServerRuntimeNICs(ctx context.Context, srv *cloudsigma.Server, runtime *server.Runtime) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	nics := make([]serverRuntimeNICModel, 0)
+	if runtime != nil {
+		for _, runtimeNIC := range runtime.NICs {
+			nic := serverRuntimeNICModel{
+				InterfaceType: stringValueOrNull(runtimeNIC.InterfaceType),
+				IO:            types.ObjectNull(serverRuntimeNICIOAttrTypes),
+				IPv4Address:   types.StringNull(),
+				IPv6Address:   types.StringNull(),
+				MAC:           stringValueOrNull(runtimeNIC.MAC),
+				VLANUUID:      types.StringNull(),
+			}
+			if runtimeNIC.IO != nil {
+				nic.IO = types.ObjectValueMust(serverRuntimeNICIOAttrTypes, map[string]attr.Value{
+					"bytes_recv":   types.Int64Value(runtimeNIC.IO.BytesRecv),
+					"bytes_sent":   types.Int64Value(runtimeNIC.IO.BytesSent),
+					"packets_recv": types.Int64Value(runtimeNIC.IO.PacketsRecv),
+					"packets_sent": types.Int64Value(runtimeNIC.IO.PacketsSent),
+				})
+			}
+			if runtimeNIC.IPv4 != nil {
+				nic.IPv4Address = stringValueOrNull(runtimeNIC.IPv4.UUID)
+			}
+			if runtimeNIC.IPv6 != nil {
+				nic.IPv6Address = stringValueOrNull(runtimeNIC.IPv6.UUID)
+			}
+			if runtimeNIC.VLAN != nil {
+				nic.VLANUUID = stringValueOrNull(runtimeNIC.VLAN.UUID)
+			} else {
+				for _, serverNIC := range srv.NICs {
+					if serverNIC.VLAN != nil && serverNIC.MACAddress != "" && serverNIC.MACAddress == runtimeNIC.MAC {
+						nic.VLANUUID = stringValueOrNull(serverNIC.VLAN.UUID)
+					}
+				}
+			}
+			nics = append(nics, nic)
+		}
+	}
+
+	list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: serverRuntimeNICAttrTypes}, nics)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// findRuntimeNIC returns the runtime network interface card with the given
+// MAC address, or nil if the server is not running.
+func findRuntimeNIC(runtime *server.Runtime, mac string) *server.RuntimeNIC {
+	if runtime == nil || mac == "" {
+		return nil
+	}
+	for i := range runtime.NICs {
+		if runtime.NICs[i].MAC == mac {
+			return &runtime.NICs[i]
+		}
+	}
+	return nil
+}
+
+// serverNetworksChanged reports whether the configured arguments of the
+// network interface cards are changed. Unknown MAC addresses and models are
+// assigned by CloudSigma and are not a change on their own.
+func serverNetworksChanged(ctx context.Context, plan, state types.List) (bool, diag.Diagnostics) {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return false, nil
+	}
+	if plan.IsNull() || state.IsNull() {
+		return true, nil
+	}
+
+	var planNetworks, stateNetworks []serverNetworkModel
+	diags := plan.ElementsAs(ctx, &planNetworks, false)
+	diags.Append(state.ElementsAs(ctx, &stateNetworks, false)...)
+	if diags.HasError() {
+		return true, diags
+	}
+
+	if len(planNetworks) != len(stateNetworks) {
+		return true, diags
+	}
+	// reordered cards are not a change, the cards are compared with the
+	// prior cards they are matched to
+	for i, j := range matchServerNetworks(planNetworks, stateNetworks) {
+		if j < 0 || !serverNetworkConfigEqual(planNetworks[i], stateNetworks[j]) {
+			return true, diags
+		}
+	}
+
+	return false, diags
+}
+
+// planServerNetworks completes the planned network interface cards with the
+// computed values of the matching prior cards. A card is matched by its MAC
+// address or, if the MAC address is not configured, by its other arguments,
+// so that reordered cards keep their identity. The runtime addresses are only
+// kept if keepRuntime is set.
+func planServerNetworks(ctx context.Context, plan, state types.List, keepRuntime bool) (types.List, diag.Diagnostics) {
+	if plan.IsNull() || plan.IsUnknown() || state.IsNull() || state.IsUnknown() {
+		return plan, nil
+	}
+
+	var planNetworks, stateNetworks []serverNetworkModel
+	diags := plan.ElementsAs(ctx, &planNetworks, false)
+	diags.Append(state.ElementsAs(ctx, &stateNetworks, false)...)
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	matches := matchServerNetworks(planNetworks, stateNetworks)
+	for i := range planNetworks {
+		if matches[i] < 0 {
+			continue
+		}
+		prior := stateNetworks[matches[i]]
+		if planNetworks[i].MAC.IsUnknown() {
+			planNetworks[i].MAC = prior.MAC
+		}
+		if planNetworks[i].Model.IsUnknown() {
+			planNetworks[i].Model = prior.Model
+		}
+		if planNetworks[i].RuntimeIPv4.IsUnknown() && keepRuntime {
+			planNetworks[i].RuntimeIPv4 = prior.RuntimeIPv4
+		}
+	}
+
+	list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: serverNetworkAttrTypes}, planNetworks)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// matchServerNetworks returns the index of the matching prior card for each
+// planned card, or -1 if there is none. A card is matched by its MAC address
+// or, if the MAC address is not configured, by its other arguments.
+func matchServerNetworks(planNetworks, stateNetworks []serverNetworkModel) []int {
+	matches := make([]int, len(planNetworks))
+	used := make([]bool, len(stateNetworks))
+	for i, network := range planNetworks {
+		matches[i] = -1
+		if network.MAC.IsUnknown() {
+			continue
+		}
+		for j, prior := range stateNetworks {
+			if !used[j] && network.MAC.Equal(prior.MAC) {
+				matches[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	for i, network := range planNetworks {
+		if matches[i] >= 0 || !network.MAC.IsUnknown() {
+			continue
+		}
+		for j, prior := range stateNetworks {
+			if !used[j] && serverNetworkConfigEqual(network, prior) {
+				matches[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// serverNetworkConfigEqual reports whether the planned network interface card
+// has the same arguments as the prior one. Unknown values are assigned by
+// CloudSigma and match any prior value.
+func serverNetworkConfigEqual(plan, prior serverNetworkModel) bool {
+	return plan.FirewallPolicy.Equal(prior.FirewallPolicy) &&
+		plan.IPv4Address.Equal(prior.IPv4Address) &&
+		plan.IPv6.Equal(prior.IPv6) &&
+		(plan.MAC.IsUnknown() || plan.MAC.Equal(prior.MAC)) &&
+		(plan.Model.IsUnknown() || plan.Model.Equal(prior.Model)) &&
+		plan.Type.Equal(prior.Type) &&
+		plan.VLANUUID.Equal(prior.VLANUUID)
+}
+
+func expandEnclavePageCaches(ctx context.Context, list types.List) ([]cloudsigma.EnclavePageCache, diag.Diagnostics) {
+	var sizes []int64
+	diags := list.ElementsAs(ctx, &sizes, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	caches := make([]cloudsigma.EnclavePageCache, 0, len(sizes))
+	for _, size := range sizes {
+		caches = append(caches, cloudsigma.EnclavePageCache{Size: int(size)})
+	}
+
+	return caches, diags
+}
+
+func flattenEnclavePageCaches(ctx context.Context, caches []cloudsigma.EnclavePageCache) (types.List, diag.Diagnostics) {
+	sizes := make([]int64, 0, len(caches))
+	for _, cache := range caches {
+		sizes = append(sizes, int64(cache.Size))
+	}
+
+	return types.ListValueFrom(ctx, types.Int64Type, sizes)
+}
+
+// serverMetaManagedKeys are the meta keys managed by dedicated attributes of
+// the server resource. They are hidden from the user-facing meta attribute.
+var serverMetaManagedKeys = []string{
+	serverMetaBase64Fields,
+	serverMetaNetworkConfig,
+	serverMetaSSHPublicKey,
+	serverMetaUserData,
+}
+
+// serverMetaCloudInitAttributes are the attributes which manage the cloud-init
+// meta keys. Defining these keys in the meta attribute is deprecated.
+var serverMetaCloudInitAttributes = map[string]string{
+	serverMetaNetworkConfig: "network_config",
+	serverMetaUserData:      "user_data",
+}
+
+// expandServerMeta builds the server meta from the meta attribute and the
+// cloud-init attributes, which are base64-encoded and added to the
+// base64_fields of the meta attribute.
+func expandServerMeta(ctx context.Context, data serverResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	var values map[string]string
+	var diags diag.Diagnostics
+	if !data.Meta.IsNull() && !data.Meta.IsUnknown() {
+		diags = data.Meta.ElementsAs(ctx, &values, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	meta := make(map[string]interface{}, len(values)+3)
+	for k, v := range values {
+		meta[k] = v
+	}
+
+	var base64Fields []string
+	for key, value := range map[string]types.String{
+		serverMetaNetworkConfig: data.NetworkConfig,
+		serverMetaUserData:      data.UserData,
+	} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		meta[key] = base64.StdEncoding.EncodeToString([]byte(value.ValueString()))
+		base64Fields = append(base64Fields, key)
+	}
+	if len(base64Fields) > 0 {
+		sort.Strings(base64Fields)
+		meta[serverMetaBase64Fields] = mergeServerBase64Fields(values[serverMetaBase64Fields], base64Fields)
+	}
+
+	return meta, diags
+}
+
+// mergeServerBase64Fields appends the keys which are missing in the
+// comma-separated base64 fields.
+func mergeServerBase64Fields(fields string, keys []string) string {
+	existing := strings.Split(fields, ",")
+	for i := range existing {
+		existing[i] = strings.TrimSpace(existing[i])
+	}
+
+	merged := fields
+	for _, key := range keys {
+		if slices.Contains(existing, key) {
+			continue
+		}
+		if merged != "" {
+			merged += ","
+		}
+		merged += key
+	}
+
+	return merged
+}
+
+// removeServerBase64Fields removes the keys from the comma-separated base64
+// fields. It reverts mergeServerBase64Fields.
+func removeServerBase64Fields(fields string, keys []string) string {
+	var kept []string
+	removed := false
+	for _, field := range strings.Split(fields, ",") {
+		if slices.Contains(keys, strings.TrimSpace(field)) {
+			removed = true
+			continue
+		}
+		kept = append(kept, field)
+	}
+	if !removed {
+		return fields
+	}
+
+	return strings.Join(kept, ",")
+}
+
+// validateServerMetaCloudInitKeys warns about the deprecated cloud-init keys in
+// meta and rejects them if the attribute managing the key is defined too.
+func validateServerMetaCloudInitKeys(ctx context.Context, data serverResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.Meta.IsNull() || data.Meta.IsUnknown() {
+		return diags
+	}
+
+	attributeValues := map[string]types.String{
+		serverMetaNetworkConfig: data.NetworkConfig,
+		serverMetaUserData:      data.UserData,
+	}
+
+	var values map[string]types.String
+	diags.Append(data.Meta.ElementsAs(ctx, &values, false)...)
+	for _, key := range []string{serverMetaBase64Fields, serverMetaNetworkConfig, serverMetaUserData} {
+		if _, ok := values[key]; !ok {
+			continue
+		}
+
+		if value, ok := attributeValues[key]; ok && !value.IsNull() {
+			diags.AddAttributeError(path.Root("meta").AtMapKey(key),
+				"Invalid meta key",
+				fmt.Sprintf("The meta key %q cannot be defined together with the %q attribute.", key, serverMetaCloudInitAttributes[key]))
+			continue
+		}
+
+		diags.AddAttributeWarning(path.Root("meta").AtMapKey(key),
+			"Deprecated meta key",
+			fmt.Sprintf("Defining the meta key %q is deprecated, use the \"user_data\" and \"network_config\" attributes instead. "+
+				"It will be rejected in a future version of the provider.", key))
+	}
+
+	return diags
+}
+
+// keepServerMetaKeys copies the values of the ignored keys from the current
+// meta of the server, which are not managed by the resource.
+func keepServerMetaKeys(meta, current map[string]interface{}, ignoreMetaKeys []string) {
+	for _, key := range ignoreMetaKeys {
+		if value, ok := current[key]; ok {
+			meta[key] = value
+		}
+	}
+}
+
+// validateServerMetaKeys checks that no ignored key is defined in meta, as it
+// would never converge.
+func validateServerMetaKeys(ctx context.Context, meta types.Map, ignoreMetaKeys []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if meta.IsNull() || meta.IsUnknown() {
+		return diags
+	}
+
+	var values map[string]types.String
+	diags.Append(meta.ElementsAs(ctx, &values, false)...)
+	for _, key := range ignoreMetaKeys {
+		if _, ok := values[key]; ok {
+			diags.AddAttributeError(path.Root("meta").AtMapKey(key),
+				"Invalid meta key",
+				fmt.Sprintf("The meta key %q is ignored by ignore_meta_keys and cannot be defined.", key))
+		}
+	}
+
+	return diags
+}
+
+// serverIgnoreMetaKeys returns the ignored meta keys of the provider and the
+// resource.
+func (r *serverResource) serverIgnoreMetaKeys(ctx context.Context, data serverResourceModel) ([]string, diag.Diagnostics) {
+	ignoreMetaKeys := slices.Clone(r.ignoreMetaKeys)
+	if data.IgnoreMetaKeys.IsNull() || data.IgnoreMetaKeys.IsUnknown() {
+		return ignoreMetaKeys, nil
+	}
+
+	var keys []string
+	diags := data.IgnoreMetaKeys.ElementsAs(ctx, &keys, false)
+	for _, key := range keys {
+		if !slices.Contains(ignoreMetaKeys, key) {
+			ignoreMetaKeys = append(ignoreMetaKeys, key)
+		}
+	}
+
+	return ignoreMetaKeys, diags
+}
+
+// serverMetaKeys returns the keys defined in the meta attribute.
+func serverMetaKeys(meta types.Map) []string {
+	keys := make([]string, 0, len(meta.Elements()))
+	for key := range meta.Elements() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// flattenServerMeta returns the meta which is not managed by dedicated
+// attributes, without the ignored keys. The deprecated cloud-init keys are
+// kept if they are defined in the meta attribute, in which case base64_fields
+// lists only the keys which are not managed by the cloud-init attributes.
+func flattenServerMeta(meta map[string]interface{}, ignoreMetaKeys, metaKeys []string) map[string]string {
+	var attributeKeys []string
+	for key := range serverMetaCloudInitAttributes {
+		if !slices.Contains(metaKeys, key) {
+			attributeKeys = append(attributeKeys, key)
+		}
+	}
+
+	values := make(map[string]string, len(meta))
+	for k, v := range meta {
+		if slices.Contains(ignoreMetaKeys, k) {
+			continue
+		}
+		// ignore keys managed by ssh_keys, user_data and network_config attributes
+		if isServerMetaManagedKey(k) && (k == serverMetaSSHPublicKey || !slices.Contains(metaKeys, k)) {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			if k == serverMetaBase64Fields {
+				s = removeServerBase64Fields(s, attributeKeys)
+			}
+			values[k] = s
+		}
+	}
+
+	return values
+}
+
+// flattenServerMetaField returns the value of the meta field with the given
+// key, decoded if the key is listed in base64_fields.
+func flattenServerMetaField(meta map[string]interface{}, key string) (string, bool) {
+	value, ok := meta[key].(string)
+	if !ok {
+		return "", false
+	}
+
+	base64Fields, _ := meta[serverMetaBase64Fields].(string)
+	for _, field := range strings.Split(base64Fields, ",") {
+		if strings.TrimSpace(field) != key {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			// keep the raw value to show the drift to the user
+			return value, true
+		}
+		return string(decoded), true
+	}
+
+	return value, true
+}
+
+func isServerMetaManagedKey(key string) bool {
+	for _, k := range serverMetaManagedKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func expandSSHKeys(ctx context.Context, set types.Set) ([]cloudsigma.Keypair, diag.Diagnostics) {
+	var uuids []string
+	diags := set.ElementsAs(ctx, &uuids, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	keypairs := make([]cloudsigma.Keypair, 0, len(uuids))
+	for _, uuid := range uuids {
+		keypairs = append(keypairs, cloudsigma.Keypair{UUID: uuid})
+	}
+
+	return keypairs, diags
+}
+
+func flattenSSHKeys(ctx context.Context, keypairs []cloudsigma.Keypair) (types.Set, diag.Diagnostics) {
+	uuids := make([]string, 0, len(keypairs))
+	for _, keypair := range keypairs {
+		uuids = append(uuids, keypair.UUID)
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, uuids)
+}
+
+func expandTags(ctx context.Context, set types.Set) ([]cloudsigma.Tag, diag.Diagnostics) {
+	var uuids []string
+	diags := set.ElementsAs(ctx, &uuids, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	tags := make([]cloudsigma.Tag, 0, len(uuids))
+	for _, uuid := range uuids {
+		tags = append(tags, cloudsigma.Tag{UUID: uuid})
+	}
+
+	return tags, diags
+}
+
+func flattenTags(ctx context.Context, tags []cloudsigma.Tag) (types.Set, diag.Diagnostics) {
+	uuids := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		uuids = append(uuids, tag.UUID)
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, uuids)
+}
+
+// expandTagNames returns the tags of the tag_names attribute, the tags which
+// do not exist yet are created and their UUIDs are returned as well.
+func expandTagNames(ctx context.Context, client *cloudsigma.Client, set types.Set) ([]cloudsigma.Tag, []string, diag.Diagnostics) {
+	var names []string
+	diags := set.ElementsAs(ctx, &names, true)
+	if diags.HasError() || len(names) == 0 {
+		return nil, nil, diags
+	}
+
+	uuids, created, err := tag.Ensure(ctx, client, names)
+	if err != nil {
+		diags.AddAttributeError(path.Root("tag_names"), "Unable to resolve tag names", err.Error())
+		return nil, nil, diags
+	}
+
+	tags := make([]cloudsigma.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, cloudsigma.Tag{UUID: uuids[name]})
+	}
+
+	return tags, created, diags
+}
+
+// expandDefaultTags returns the default tags of the provider.
+func expandDefaultTags(uuids []string) []cloudsigma.Tag {
+	tags := make([]cloudsigma.Tag, 0, len(uuids))
+	for _, uuid := range uuids {
+		tags = append(tags, cloudsigma.Tag{UUID: uuid})
+	}
+	return tags
+}
+
+// mergeTags returns the tags of the tags and tag_names attributes and the
+// default tags without duplicates.
+func mergeTags(tags ...[]cloudsigma.Tag) []cloudsigma.Tag {
+	merged := make([]cloudsigma.Tag, 0)
+	for _, t := range slices.Concat(tags...) {
+		if !slices.ContainsFunc(merged, func(m cloudsigma.Tag) bool { return m.UUID == t.UUID }) {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
+// readTags returns the tags and tag_names attributes of the applied tags.
+// The tag names are only looked up if tag_names is used.
+func readTags(ctx context.Context, client *cloudsigma.Client, tags []cloudsigma.Tag, priorTags, priorTagNames types.Set, defaultTags []string) (types.Set, types.Set, diag.Diagnostics) {
+	var names map[string]string
+	if !priorTagNames.IsNull() {
+		var err error
+		names, err = tag.Names(ctx, client)
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Unable to get tag names", err.Error())
+			return priorTags, priorTagNames, diags
+		}
+	}
+
+	return flattenTagNames(ctx, tags, names, priorTags, priorTagNames, defaultTags)
+}
+
+// flattenTagNames splits the applied tags into the tags and tag_names
+// attributes. A tag is listed in tag_names if its name was listed before, and
+// in tags if it was listed before or is neither applied by name nor a default
+// tag of the provider.
+func flattenTagNames(ctx context.Context, tags []cloudsigma.Tag, names map[string]string, priorTags, priorTagNames types.Set, defaultTags []string) (types.Set, types.Set, diag.Diagnostics) {
+	var priorUUIDs, priorNames []string
+	diags := priorTags.ElementsAs(ctx, &priorUUIDs, true)
+	diags.Append(priorTagNames.ElementsAs(ctx, &priorNames, true)...)
+	if diags.HasError() {
+		return priorTags, priorTagNames, diags
+	}
+
+	uuids := make([]string, 0, len(tags))
+	tagNames := make([]string, 0, len(tags))
+	for _, t := range tags {
+		name, byName := names[t.UUID], slices.Contains(priorNames, names[t.UUID])
+		if byName && !slices.Contains(tagNames, name) {
+			tagNames = append(tagNames, name)
+		}
+		if (!byName && !slices.Contains(defaultTags, t.UUID)) || slices.Contains(priorUUIDs, t.UUID) {
+			uuids = append(uuids, t.UUID)
+		}
+	}
+
+	flattenedTags, d := types.SetValueFrom(ctx, types.StringType, uuids)
+	diags.Append(d...)
+	if priorTagNames.IsNull() {
+		return flattenedTags, priorTagNames, diags
+	}
+	flattenedTagNames, d := types.SetValueFrom(ctx, types.StringType, tagNames)
+	diags.Append(d...)
+
+	return flattenedTags, flattenedTagNames, diags
+}
+
+// planTagsAll returns the planned tags_all attribute, which is unknown if the
+// applied tags are not known yet, e.g. if tags of tag_names must be created.
+func planTagsAll(ctx context.Context, client *cloudsigma.Client, defaultTags []string, tags, tagNames types.Set) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if tags.IsUnknown() || tagNames.IsUnknown() {
+		return types.SetUnknown(types.StringType), diags
+	}
+
+	var uuids, names []string
+	diags.Append(tags.ElementsAs(ctx, &uuids, true)...)
+	diags.Append(tagNames.ElementsAs(ctx, &names, true)...)
+	if diags.HasError() {
+		return types.SetUnknown(types.StringType), diags
+	}
+
+	if len(names) > 0 {
+		// the client is not configured yet if the provider configuration is unknown
+		if client == nil {
+			return types.SetUnknown(types.StringType), diags
+		}
+		tagUUIDs, err := tag.Lookup(ctx, client, names)
+		if err != nil {
+			diags.AddError("Unable to resolve tag names", err.Error())
+			return types.SetUnknown(types.StringType), diags
+		}
+		for _, name := range names {
+			tagUUID, ok := tagUUIDs[name]
+			if !ok {
+				return types.SetUnknown(types.StringType), diags
+			}
+			uuids = append(uuids, tagUUID)
+		}
+	}
+
+	tagsAll := make([]string, 0, len(uuids)+len(defaultTags))
+	for _, uuid := range slices.Concat(uuids, defaultTags) {
+		if !slices.Contains(tagsAll, uuid) {
+			tagsAll = append(tagsAll, uuid)
+		}
+	}
+
+	tagsAllSet, d := types.SetValueFrom(ctx, types.StringType, tagsAll)
+	diags.Append(d...)
+	return tagsAllSet, diags
+}
+
+// modifyPlanTagsAll plans the tags_all attribute of a resource from its tags
+// and tag_names attributes. The default tags of the provider may have changed
+// without any change of the resource configuration, so tags_all is planned on
+// every plan but destroy. It reports whether the planned tags_all changed.
+func modifyPlanTagsAll(ctx context.Context, client *cloudsigma.Client, defaultTags []string, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) bool {
+	if request.Plan.Raw.IsNull() {
+		return false
+	}
+
+	var tags, tagsAll types.Set
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)...)
+	tagNames := types.SetNull(types.StringType)
+	if _, ok := request.Plan.Schema.GetAttributes()["tag_names"]; ok {
+		response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("tag_names"), &tagNames)...)
+	}
+	if response.Diagnostics.HasError() {
+		return false
+	}
+
+	plannedTagsAll, diags := planTagsAll(ctx, client, defaultTags, tags, tagNames)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return false
+	}
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("tags_all"), plannedTagsAll)...)
+
+	return !plannedTagsAll.Equal(tagsAll)
+}
+
+// deleteUnusedTagNames deletes the tags of the tag_names attribute which are
+// not applied to any resource anymore.
+func deleteUnusedTagNames(ctx context.Context, client *cloudsigma.Client, set types.Set) diag.Diagnostics {
+	var names []string
+	diags := set.ElementsAs(ctx, &names, true)
+	if diags.HasError() || len(names) == 0 {
+		return diags
+	}
+
+	tflog.Trace(ctx, "Deleting unused tags", map[string]any{"tag_names": names})
+	err := tag.DeleteUnused(ctx, client, names)
+	if err != nil {
+		diags.AddError("Unable to delete unused tags", err.Error())
+	}
+
+	return diags
+}
+
+func findIPv6Address(srv *cloudsigma.Server, addrType string) string {
+	if srv.Runtime == nil {
+		return ""
+	}
+
+	for _, nic := range srv.Runtime.RuntimeNICs {
+		if nic.InterfaceType == addrType && nic.IPv6.UUID != "" {
+			return nic.IPv6.UUID
+		}
+	}
+	return ""
+}
+
+func findIPv4Address(srv *cloudsigma.Server, addrType string) string {
+	if srv.Runtime == nil {
+		return ""
+	}
+
+	for _, nic := range srv.Runtime.RuntimeNICs {
+		if nic.InterfaceType == addrType {
+			return nic.IPv4.UUID
+		}
+	}
+	return ""
+}
+
+// serverNeedsRestart reports whether the planned changes can only be applied
+// to a stopped server. Only name, meta, tags and hot-pluggable drives can be
+// changed on a running server.
+func serverNeedsRestart(ctx context.Context, plan, state serverResourceModel) (bool, diag.Diagnostics) {
+	hasBootDisk := !plan.BootDisk.IsNull() && !state.BootDisk.IsNull()
+	drivesNeedRestart, diags := serverDrivesNeedRestart(ctx, plan.Drives, state.Drives, hasBootDisk)
+	bootDiskResized, d := serverBootDiskResized(ctx, plan.BootDisk, state.BootDisk)
+	diags.Append(d...)
+	networksChanged, d := serverNetworksChanged(ctx, plan.Networks, state.Networks)
+	diags.Append(d...)
+
+	// computed CPU options stay unknown if the API did not report them
+	// before, these are only changed if they are configured
+	return drivesNeedRestart || bootDiskResized || networksChanged ||
+		!plan.CPU.Equal(state.CPU) ||
+		(!plan.CPUModel.IsUnknown() && !plan.CPUModel.Equal(state.CPUModel)) ||
+		(!plan.CPUType.IsUnknown() && !plan.CPUType.Equal(state.CPUType)) ||
+		!plan.CPUsInsteadOfCores.Equal(state.CPUsInsteadOfCores) ||
+		!plan.EnableNuma.Equal(state.EnableNuma) ||
+		!plan.EnclavePageCaches.Equal(state.EnclavePageCaches) ||
+		!plan.HVRelaxed.Equal(state.HVRelaxed) ||
+		!plan.HVTSC.Equal(state.HVTSC) ||
+		(!plan.Hypervisor.IsUnknown() && !plan.Hypervisor.Equal(state.Hypervisor)) ||
+		!plan.Memory.Equal(state.Memory) ||
+		!plan.SMP.Equal(state.SMP) ||
+		!plan.SSHKeys.Equal(state.SSHKeys) ||
+		!plan.VNCPassword.Equal(state.VNCPassword), diags
+}
+
+// serverDrivesNeedRestart reports whether the planned drive changes can only
+// be applied to a stopped server. Data drives using virtio or scsi emulation
+// can be hot-plugged, if they are added to or removed from the end of the list
+// and the drives before them stay unchanged. If the server boots from the
+// boot_disk, all drives of the list are data drives.
+func serverDrivesNeedRestart(ctx context.Context, plan, state types.List, hasBootDisk bool) (bool, diag.Diagnostics) {
+	if plan.Equal(state) {
+		return false, nil
+	}
+	if plan.IsUnknown() {
+		return true, nil
+	}
+
+	var planDrives, stateDrives []serverDriveModel
+	diags := plan.ElementsAs(ctx, &planDrives, true)
+	diags.Append(state.ElementsAs(ctx, &stateDrives, true)...)
+	if diags.HasError() {
+		return true, diags
+	}
+
+	var unchanged, changed []serverDriveModel
+	if len(planDrives) > len(stateDrives) {
+		unchanged, changed = stateDrives, planDrives[len(stateDrives):]
+	} else {
+		unchanged, changed = planDrives, stateDrives[len(planDrives):]
+	}
+	// the boot drive cannot be hot-plugged
+	if len(unchanged) == 0 && !hasBootDisk {
+		return true, diags
+	}
+	for i, drive := range unchanged {
+		if drive != stateDrives[i] || drive != planDrives[i] {
+			return true, diags
+		}
+	}
+	for _, drive := range changed {
+		if !isServerDriveHotPluggable(drive.Device.ValueString()) {
+			return true, diags
+		}
+	}
+
+	return false, diags
+}
+
+func isServerDriveHotPluggable(device string) bool {
+	return device == "virtio" || device == "scsi"
+}
+
+// validateServerSMP validates the minimum amount of cpu per smp.
+func validateServerSMP(data serverResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.CPU.IsUnknown() || data.SMP.IsUnknown() {
+		return diags
+	}
+	if smp := data.SMP.ValueInt64(); smp > 0 {
+		if cpu := data.CPU.ValueInt64(); cpu/smp < 1000 {
+			diags.AddAttributeError(path.Root("smp"), "Invalid server configuration",
+				fmt.Sprintf("the minimum amount of cpu per smp is 1000 (currently is %v)", cpu/smp))
+		}
+	}
+
+	return diags
+}
+
+// validateServerNetworks validates the configuration of the network interface
+// cards. Values unknown during validation are skipped.
+func validateServerNetworks(ctx context.Context, list types.List) diag.Diagnostics {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var networks []types.Object
+	diags := list.ElementsAs(ctx, &networks, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, object := range networks {
+		if object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		var network serverNetworkModel
+		diags.Append(object.As(ctx, &network, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+		if diags.HasError() {
+			return diags
+		}
+
+		networkPath := path.Root("network").AtListIndex(i)
+		hasVLAN := !network.VLANUUID.IsUnknown() && network.VLANUUID.ValueString() != ""
+		if network.Type.ValueString() == "static" && !network.IPv4Address.IsUnknown() && network.IPv4Address.ValueString() == "" {
+			diags.AddAttributeError(networkPath.AtName("ipv4_address"), "Invalid network configuration",
+				"network address cannot be empty if type is static")
+		}
+		if !network.Type.IsNull() && !network.Type.IsUnknown() && hasVLAN {
+			diags.AddAttributeError(networkPath.AtName("vlan_uuid"), "Invalid network configuration",
+				"cannot assign both network type and vlan")
+		}
+
+		if network.IPv6.IsNull() || network.IPv6.IsUnknown() {
+			continue
+		}
+		var ipv6 serverNetworkIPv6Model
+		diags.Append(network.IPv6.As(ctx, &ipv6, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+		if diags.HasError() {
+			return diags
+		}
+		if ipv6.Type.ValueString() == "static" && !ipv6.Address.IsUnknown() && ipv6.Address.ValueString() == "" {
+			diags.AddAttributeError(networkPath.AtName("ipv6").AtName("address"), "Invalid network configuration",
+				"ipv6 address cannot be empty if type is static")
+		}
+		if ipv6Type := ipv6.Type.ValueString(); (ipv6Type == "dhcp" || ipv6Type == "static") && hasVLAN {
+			diags.AddAttributeError(networkPath.AtName("ipv6"), "Invalid network configuration",
+				"cannot assign both ipv6 type and vlan")
+		}
+	}
+
+	return diags
+}
+
+// serverDriveDevices are the supported device emulation types of drives.
+var serverDriveDevices = []string{"ide", "virtio", "scsi"}
+
+// validateServerDrives validates the device and boot order of the drives and
+// that every drive is only attached once with a single boot order. Values
+// unknown during validation are skipped.
+func validateServerDrives(ctx context.Context, list types.List) diag.Diagnostics {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var drives []types.Object
+	diags := list.ElementsAs(ctx, &drives, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	driveIndexes := make(map[string]int, len(drives))
+	bootOrderIndexes := make(map[int64]int, len(drives))
+	for i, object := range drives {
+		if object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		var drive serverDriveModel
+		d := object.As(ctx, &drive, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+		diags.Append(d...)
+		if d.HasError() {
+			return diags
+		}
+
+		drivePath := path.Root("drive").AtListIndex(i)
+		if isKnownString(drive.Device) && !slices.Contains(serverDriveDevices, drive.Device.ValueString()) {
+			diags.AddAttributeError(drivePath.AtName("device"), "Invalid drive configuration",
+				fmt.Sprintf("device %q is not supported, valid values are %s",
+					drive.Device.ValueString(), strings.Join(serverDriveDevices, ", ")))
+		}
+
+		if !drive.BootOrder.IsNull() && !drive.BootOrder.IsUnknown() {
+			bootOrder := drive.BootOrder.ValueInt64()
+			if bootOrder < 1 {
+				diags.AddAttributeError(drivePath.AtName("boot_order"), "Invalid drive configuration",
+					fmt.Sprintf("boot_order must be at least 1, got %d", bootOrder))
+			} else if j, ok := bootOrderIndexes[bootOrder]; ok {
+				diags.AddAttributeError(drivePath.AtName("boot_order"), "Invalid drive configuration",
+					fmt.Sprintf("boot_order %d is already used by drive[%d]", bootOrder, j))
+			} else {
+				bootOrderIndexes[bootOrder] = i
+			}
+		}
+
+		if drive.UUID.IsNull() || drive.UUID.IsUnknown() {
+			continue
+		}
+		if j, ok := driveIndexes[drive.UUID.ValueString()]; ok {
+			diags.AddAttributeError(drivePath.AtName("uuid"), "Invalid drive configuration",
+				fmt.Sprintf("drive %s is already attached as drive[%d] with boot_order %d",
+					drive.UUID.ValueString(), j, serverDriveBootOrder(j)))
+			continue
+		}
+		driveIndexes[drive.UUID.ValueString()] = i
+	}
+
+	return diags
+}
+
+// validateServerBootDiskSize validates that the boot disk is not smaller than
+// the library drive it is cloned from.
+func validateServerBootDiskSize(bootDisk serverBootDiskModel, libraryDrive *cloudsigma.LibraryDrive) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if bootDisk.Size.ValueInt64() < int64(libraryDrive.Size) {
+		diags.AddAttributeError(path.Root("boot_disk").AtName("size"), "Invalid boot disk size",
+			fmt.Sprintf("The boot disk must not be smaller than the library drive %q of %d bytes, got %d bytes.",
+				libraryDrive.UUID, libraryDrive.Size, bootDisk.Size.ValueInt64()))
+	}
+	return diags
+}
+
+// validateServerCapabilities validates the hypervisor and the CPU type of the
+// server, and the cpu, memory and smp values against the limits of the chosen
+// host type.
+func validateServerCapabilities(capabilities *cloudsigma.Capabilities, data serverResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if isKnownString(data.Hypervisor) {
+		if capabilities.Hypervisors == nil || len(capabilities.Hypervisors.KVM) == 0 {
+			diags.AddAttributeError(path.Root("hypervisor"), "Unsupported hypervisor",
+				fmt.Sprintf("The %q hypervisor is not available in this location.", data.Hypervisor.ValueString()))
+		} else if isKnownString(data.CPUType) && !slices.Contains(capabilities.Hypervisors.KVM, data.CPUType.ValueString()) {
+			diags.AddAttributeError(path.Root("cpu_type"), "Unsupported CPU type",
+				fmt.Sprintf("The %q hypervisor does not support %q hosts, supported CPU types are: %s.",
+					data.Hypervisor.ValueString(), data.CPUType.ValueString(), strings.Join(capabilities.Hypervisors.KVM, ", ")))
+		}
+	}
+
+	if !isKnownString(data.CPUType) {
+		return diags
+	}
+
+	var host *cloudsigma.CapabilitiesHost
+	if capabilities.Hosts != nil {
+		switch data.CPUType.ValueString() {
+		case serverCPUTypeAMD:
+			host = capabilities.Hosts.AMD
+		case serverCPUTypeIntel:
+			host = capabilities.Hosts.Intel
+		}
+	}
+	if host == nil {
+		diags.AddAttributeError(path.Root("cpu_type"), "Unsupported CPU type",
+			fmt.Sprintf("There are no %q hosts available in this location.", data.CPUType.ValueString()))
+		return diags
+	}
+
+	validateLimitation := func(attributeName string, value types.Int64, limitation *cloudsigma.CapabilitiesLimitation) {
+		if limitation == nil || value.IsNull() || value.IsUnknown() {
+			return
+		}
+		if v := value.ValueInt64(); v < int64(limitation.Min) || (limitation.Max > 0 && v > int64(limitation.Max)) {
+			diags.AddAttributeError(path.Root(attributeName), "Invalid server configuration",
+				fmt.Sprintf("The %s of a server on %q hosts must be between %d and %d, got: %d.",
+					attributeName, data.CPUType.ValueString(), limitation.Min, limitation.Max, v))
+		}
+	}
+	validateLimitation("cpu", data.CPU, host.CPU)
+	validateLimitation("memory", data.Memory, host.Memory)
+	validateLimitation("smp", data.SMP, host.SMP)
+
+	if smp := data.SMP.ValueInt64(); host.CPUPerSMP != nil && smp > 0 && !data.CPU.IsUnknown() {
+		if cpuPerSMP := data.CPU.ValueInt64() / smp; cpuPerSMP < int64(host.CPUPerSMP.Min) ||
+			(host.CPUPerSMP.Max > 0 && cpuPerSMP > int64(host.CPUPerSMP.Max)) {
+			diags.AddAttributeError(path.Root("cpu"), "Invalid server configuration",
+				fmt.Sprintf("The cpu per smp of a server on %q hosts must be between %d and %d, got: %d.",
+					data.CPUType.ValueString(), host.CPUPerSMP.Min, host.CPUPerSMP.Max, cpuPerSMP))
+		}
+	}
+
+	return diags
+}
+
+// serverDeleteRecurse returns the recursive delete mode of the API for the
+// delete_drives_on_destroy value.
+func serverDeleteRecurse(deleteDrives string) string {
+	switch deleteDrives {
+	case serverDeleteDrivesDisks:
+		return server.RecurseDisks
+	case serverDeleteDrivesAll:
+		return server.RecurseAllDrives
+	default:
+		return server.RecurseNone
+	}
+}
+
+// setServerPowerState starts or stops the server to reach the desired power
+// state.
+func setServerPowerState(ctx context.Context, client *cloudsigma.Client, serverUUID string, data serverResourceModel) error {
+	if data.PowerState.ValueString() == serverPowerStateStopped {
+		return stopServer(ctx, client, serverUUID, data.ShutdownMethod, data.ShutdownTimeout)
+	}
+	return server.Start(ctx, client, serverUUID)
+}
+
+// stopServer stops the server with the given shutdown method. The ACPI
+// shutdown falls back to a hard stop if the server is still running after
+// the shutdown timeout.
+func stopServer(ctx context.Context, client *cloudsigma.Client, serverUUID string, method types.String, timeout types.Int64) error {
+	if method.ValueString() == serverShutdownMethodACPI {
+		return server.Shutdown(ctx, client, serverUUID, time.Duration(timeout.ValueInt64())*time.Second)
+	}
+	return server.Stop(ctx, client, serverUUID)
+}
+
+// serverShutdownMethodSchemaAttribute returns the schema of the shutdown_method
+// attribute shared by the resources stopping servers.
+func serverShutdownMethodSchemaAttribute(usage string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: usage + " " +
+			"`acpi` requests a graceful ACPI shutdown and stops the server only if it is still running after " +
+			"`shutdown_timeout`, `stop` powers the server off immediately. Valid values: `acpi`, `stop`(default).",
+		Computed: true,
+		Optional: true,
+		Default:  stringdefault.StaticString(serverShutdownMethodStop),
+		Validators: []validator.String{
+			stringvalidator.OneOf(serverShutdownMethodACPI, serverShutdownMethodStop),
+		},
+	}
+}
+
+// serverShutdownTimeoutSchemaAttribute returns the schema of the
+// shutdown_timeout attribute shared by the resources stopping servers.
+func serverShutdownTimeoutSchemaAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: "Time in seconds to wait for the ACPI shutdown before the server is stopped. " +
+			"Only used with `shutdown_method = \"acpi\"`. Defaults to `300`.",
+		Computed: true,
+		Optional: true,
+		Default:  int64default.StaticInt64(serverDefaultShutdownTimeout),
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+}
+
+// serverStatusFromPowerState returns a plan modifier that plans the status of
+// the server from its desired power state.
+func serverStatusFromPowerState() planmodifier.String {
+	return serverStatusModifier{}
+}
+
+type serverStatusModifier struct{}
+
+func (m serverStatusModifier) Description(_ context.Context) string {
+	return "Status is planned from the desired power state of the server."
+}
+
+func (m serverStatusModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m serverStatusModifier) PlanModifyString(ctx context.Context, request planmodifier.StringRequest, response *planmodifier.StringResponse) {
+	var powerState types.String
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("power_state"), &powerState)...)
+	if response.Diagnostics.HasError() || powerState.IsNull() || powerState.IsUnknown() {
+		return
+	}
+
+	response.PlanValue = powerState
+}
+
+// serverDriveBootOrder returns the boot order of the drive at the given
+// position of the drive list.
+func serverDriveBootOrder(index int) int {
+	return index + 1
+}
+
+// requiresReplaceIfServerBootDiskAddedOrRemoved requires replacement if the
+// boot disk is added to or removed from an existing server.
+func requiresReplaceIfServerBootDiskAddedOrRemoved(_ context.Context, request planmodifier.ObjectRequest, response *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	response.RequiresReplace = request.StateValue.IsNull() != request.PlanValue.IsNull()
+}
+
+// requiresReplaceIfServerBootDiskShrinks requires replacement if the boot disk
+// is planned smaller than it is, drives can only grow.
+func requiresReplaceIfServerBootDiskShrinks(_ context.Context, request planmodifier.Int64Request, response *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	response.RequiresReplace = !request.PlanValue.IsUnknown() && !request.StateValue.IsNull() &&
+		request.PlanValue.ValueInt64() < request.StateValue.ValueInt64()
+}
+
+// serverDriveDevChannel returns the device channel of the drive at the given
+// position of the drive list.
+func serverDriveDevChannel(index int) string {
+	return fmt.Sprintf("0:%d", index+1)
+}
+
+// serverDriveIndex returns the position of the drive in the drive list from
+// the path of one of its nested attributes.
+func serverDriveIndex(p path.Path) (int, bool) {
+	steps := p.Steps()
+	if len(steps) < 2 {
+		return 0, false
+	}
+	step, ok := steps[len(steps)-2].(path.PathStepElementKeyInt)
+	return int(step), ok
+}
+
+// serverDriveBootOrderFromPosition returns a plan modifier that sets the boot
+// order of the drive the same way as it is assigned when attaching drives.
+func serverDriveBootOrderFromPosition() planmodifier.Int64 {
+	return serverDriveBootOrderModifier{}
+}
+
+type serverDriveBootOrderModifier struct{}
+
+func (m serverDriveBootOrderModifier) Description(_ context.Context) string {
+	return "Boot order is derived from the position of the drive."
+}
+
+func (m serverDriveBootOrderModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m serverDriveBootOrderModifier) PlanModifyInt64(ctx context.Context, request planmodifier.Int64Request, response *planmodifier.Int64Response) {
+	index, ok := serverDriveIndex(request.Path)
+	if !ok {
+		return
+	}
+
+	// the boot disk boots before the drives of the list
+	if !request.Plan.Raw.IsNull() {
+		var bootDisk types.Object
+		response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("boot_disk"), &bootDisk)...)
+		if !bootDisk.IsNull() {
+			index++
+		}
+	}
+	response.PlanValue = types.Int64Value(int64(serverDriveBootOrder(index)))
+}
+
+// serverDriveDevChannelFromPosition returns a plan modifier that sets the
+// device channel of the drive the same way as it is assigned when attaching
+// drives.
+func serverDriveDevChannelFromPosition() planmodifier.String {
+	return serverDriveDevChannelModifier{}
+}
+
+type serverDriveDevChannelModifier struct{}
+
+func (m serverDriveDevChannelModifier) Description(_ context.Context) string {
+	return "Device channel is derived from the position of the drive."
+}
+
+func (m serverDriveDevChannelModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m serverDriveDevChannelModifier) PlanModifyString(_ context.Context, request planmodifier.StringRequest, response *planmodifier.StringResponse) {
+	if index, ok := serverDriveIndex(request.Path); ok {
+		response.PlanValue = types.StringValue(serverDriveDevChannel(index))
+	}
+}
+
+// upgradeTimeoutsV0 adds the delete timeout, which is missing in the state of
+// version 0, to the configured create and update timeouts.
+func upgradeTimeoutsV0(prior timeouts.Value) timeouts.Value {
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"delete": types.StringType,
+		"update": types.StringType,
+	}
+	if prior.IsNull() || prior.IsUnknown() {
+		return timeouts.Value{Object: types.ObjectNull(attrTypes)}
+	}
+
+	attrs := map[string]attr.Value{
+		"create": types.StringNull(),
+		"delete": types.StringNull(),
+		"update": types.StringNull(),
+	}
+	for name, value := range prior.Attributes() {
+		attrs[name] = value
+	}
+	return timeouts.Value{Object: types.ObjectValueMust(attrTypes, attrs)}
+}
+
+func isKnownString(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
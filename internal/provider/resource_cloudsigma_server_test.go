@@ -0,0 +1,2607 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	acc "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/server"
+)
+
+func init() {
+	acc.AddTestSweepers("cloudsigma_server", &acc.Sweeper{
+		Name: "cloudsigma_server",
+		F:    testSweepServers,
+	})
+}
+
+func testSweepServers(region string) error {
+	ctx := context.Background()
+	client, err := sharedClient(region)
+	if err != nil {
+		return err
+	}
+
+	servers, _, err := client.Servers.List(ctx)
+	if err != nil {
+		return fmt.Errorf("getting server list: %w", err)
+	}
+
+	for _, srv := range servers {
+		if strings.HasPrefix(srv.Name, accTestPrefix) {
+			slog.Info("Deleting cloudsigma_server", "name", srv.Name, "uuid", srv.UUID)
+			if err := server.Stop(ctx, client, srv.UUID); err != nil {
+				slog.Warn("Error stopping server during sweep", "name", srv.Name, "error", err)
+				continue
+			}
+			_, err := client.Servers.Delete(ctx, srv.UUID)
+			if err != nil {
+				slog.Warn("Error deleting server during sweep", "name", srv.Name, "error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestAccResourceCloudSigmaServer_basic(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	tagName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResource(serverName),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "cpu", "2000"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "memory", "536870912"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "name", serverName),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "power_state", "running"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "runtime_nics.#", "1"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "runtime_nics.0.interface_type", "public"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "status", "running"),
+					acc.TestCheckResourceAttrSet("cloudsigma_server.test", "id"),
+					acc.TestCheckResourceAttrSet("cloudsigma_server.test", "resource_uri"),
+				),
+			},
+			testAccCloudSigmaServerImportStep,
+			{
+				Config: testAccCloudSigmaServerResourceWithTag(tagName, serverName),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "tags.#", "1"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithoutTags(serverName),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_smp(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResource(serverName),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "cpu", "2000"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithSMP(serverName, 2),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "smp", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_cpuOptions(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithCPUOptions(serverName, false),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "cpu_type", "amd"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "hypervisor", "kvm"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "enable_numa", "false"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithCPUOptions(serverName, true),
+				ConfigPlanChecks: acc.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("cloudsigma_server.test",
+							tfjsonpath.New("pending_restart"), knownvalue.Bool(true)),
+					},
+				},
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "cpus_instead_of_cores", "true"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "enable_numa", "true"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "hv_relaxed", "true"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "hv_tsc", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_powerState(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithPowerState(serverName, "stopped"),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "power_state", "stopped"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "status", "stopped"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithPowerState(serverName, "running"),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "power_state", "running"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_pendingRestart(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResource(serverName),
+				ConfigPlanChecks: acc.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("cloudsigma_server.test", tfjsonpath.New("pending_restart"), knownvalue.Bool(false)),
+					},
+				},
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithSMP(serverName, 2),
+				ConfigPlanChecks: acc.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("cloudsigma_server.test", tfjsonpath.New("pending_restart"), knownvalue.Bool(true)),
+					},
+				},
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "status", "running"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithMeta(serverName),
+				ConfigPlanChecks: acc.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("cloudsigma_server.test", tfjsonpath.New("pending_restart"), knownvalue.Bool(false)),
+					},
+				},
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_shutdownMethod(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithShutdownMethod(serverName, 2000),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "shutdown_method", "acpi"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "shutdown_timeout", "60"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithShutdownMethod(serverName, 3000),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "cpu", "3000"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_withDrive(t *testing.T) {
+	var srv cloudsigma.Server
+	var drive cloudsigma.Drive
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithDrive(serverName, driveName, 5),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "drive.#", "1"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "drive.0.boot_order", "1"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "drive.0.dev_channel", "0:1"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "drive.0.device", "virtio"),
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					acc.TestCheckResourceAttr("cloudsigma_drive.test", "size", "5368709120"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithDrive(serverName, driveName, 15),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					acc.TestCheckResourceAttr("cloudsigma_drive.test", "size", "16106127360"),
+				),
+			},
+			testAccCloudSigmaServerImportStep,
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_deleteDrivesOnDestroy(t *testing.T) {
+	var srv cloudsigma.Server
+	var drive cloudsigma.Drive
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckServerDestroy(s); err != nil {
+				return err
+			}
+			// the drive is deleted by the server, before its own destroy
+			client, err := sharedClient("testacc")
+			if err != nil {
+				return err
+			}
+			if _, resp, err := client.Drives.Get(context.Background(), drive.UUID); err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+				return fmt.Errorf("drive (%s) still exists", drive.UUID)
+			}
+			return nil
+		},
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithDeleteDrivesOnDestroy(serverName, driveName, "disks"),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					testAccCheckDriveExists("cloudsigma_drive.test", &drive),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "delete_drives_on_destroy", "disks"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_bootDisk(t *testing.T) {
+	var srv cloudsigma.Server
+	var bootDiskUUID string
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckServerDestroy(s); err != nil {
+				return err
+			}
+			client, err := sharedClient("testacc")
+			if err != nil {
+				return err
+			}
+			if _, resp, err := client.Drives.Get(context.Background(), bootDiskUUID); err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+				return fmt.Errorf("boot disk (%s) still exists", bootDiskUUID)
+			}
+			return nil
+		},
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithBootDisk(serverName, 10),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "boot_disk.size", "10737418240"),
+					acc.TestCheckResourceAttrSet("cloudsigma_server.test", "boot_disk.storage_type"),
+					acc.TestCheckResourceAttrSet("cloudsigma_server.test", "boot_disk.uuid"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "drive.#", "0"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "status", "running"),
+					func(s *terraform.State) error {
+						bootDiskUUID = s.RootModule().Resources["cloudsigma_server.test"].Primary.Attributes["boot_disk.uuid"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithBootDisk(serverName, 15),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "boot_disk.size", "16106127360"),
+					acc.TestCheckResourceAttrPtr("cloudsigma_server.test", "boot_disk.uuid", &bootDiskUUID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_withMeta(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithMeta(serverName),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.%", "1"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.random-key", "random-value"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "user_data", "#cloud-config\n"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithChangedMeta(serverName),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.%", "2"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.another-key", "another-value"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.random-key", "random-value"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "network_config", "version: 2\n"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "user_data", "#cloud-config\n"),
+				),
+			},
+			{
+				Config: testAccCloudSigmaServerResourceWithEmptyMeta(serverName),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.%", "0"),
+					acc.TestCheckNoResourceAttr("cloudsigma_server.test", "user_data"),
+					func(_ *terraform.State) error {
+						if len(srv.Meta) > 0 {
+							return fmt.Errorf("server meta is not empty: %v", srv.Meta)
+						}
+						return nil
+					},
+				),
+			},
+			testAccCloudSigmaServerImportStep,
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_ignoreMetaKeys(t *testing.T) {
+	var srv cloudsigma.Server
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config: testAccCloudSigmaServerResourceWithIgnoreMetaKeys(serverName, "random-value"),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.%", "1"),
+				),
+			},
+			{
+				// the key is set by an agent inside the guest
+				PreConfig: func() {
+					client, err := sharedClient("testacc")
+					if err != nil {
+						t.Fatal(err)
+					}
+					srv.Meta["agent-key"] = "agent-value"
+					if _, _, err := client.Servers.Update(context.Background(), srv.UUID, &cloudsigma.ServerUpdateRequest{Server: &srv}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccCloudSigmaServerResourceWithIgnoreMetaKeys(serverName, "changed-value"),
+				Check: acc.ComposeAggregateTestCheckFunc(
+					testAccCheckServerExists("cloudsigma_server.test", &srv),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.%", "1"),
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "meta.random-key", "changed-value"),
+					func(_ *terraform.State) error {
+						if srv.Meta["agent-key"] != "agent-value" {
+							return fmt.Errorf("ignored meta key is not kept: %v", srv.Meta)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_expectError(t *testing.T) {
+	acc.ParallelTest(t, acc.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				Config:      testAccCloudSigmaServerResourceWithEmptySSHKey(),
+				ExpectError: regexp.MustCompile(`ssh_keys.* string length must be at least 1`),
+			},
+			{
+				Config:      testAccCloudSigmaServerResourceWithEmptyTag(),
+				ExpectError: regexp.MustCompile(`tags.* string length must be at least 1`),
+			},
+			{
+				Config:      testAccCloudSigmaServerResourceWithSMP(fmt.Sprintf("%s-invalid-smp", accTestPrefix), 5),
+				ExpectError: regexp.MustCompile("the minimum amount of cpu per smp is .*"),
+			},
+			{
+				Config:      testAccCloudSigmaServerResourceWithStaticNetworkWithoutAddress(),
+				ExpectError: regexp.MustCompile("network address cannot be empty if type is static"),
+			},
+			{
+				Config:      testAccCloudSigmaServerResourceWithDuplicateDrive(),
+				ExpectError: regexp.MustCompile(`is already attached as drive\[0\]`),
+			},
+		},
+	})
+}
+
+func TestAccResourceCloudSigmaServer_upgradeFromSDK(t *testing.T) {
+	serverName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	acc.Test(t, acc.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckServerDestroy,
+
+		Steps: []acc.TestStep{
+			{
+				ExternalProviders: map[string]acc.ExternalProvider{
+					"cloudsigma": {
+						VersionConstraint: "2.9.0",
+						Source:            "cloudsigma/cloudsigma",
+					},
+				},
+				Config: testAccCloudSigmaServerResourceWithMeta(serverName),
+				Check: acc.ComposeTestCheckFunc(
+					acc.TestCheckResourceAttr("cloudsigma_server.test", "name", serverName),
+				),
+			},
+			{
+				ProtoV6ProviderFactories: testAccProviderFactories,
+				Config:                   testAccCloudSigmaServerResourceWithMeta(serverName),
+				ConfigPlanChecks: acc.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestServerResource_modifyPlanRuntimeAddresses(t *testing.T) {
+	ctx := context.Background()
+	r := &serverResource{}
+
+	var schemaResponse resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+
+	rawValue := func(powerState string) tftypes.Value {
+		raw := []byte(fmt.Sprintf(`{
+  "cpu": 2000,
+  "id": "2d3a2c8c-d8a8-4ec7-9d85-5e1c4c2ad7fb",
+  "ipv4_address": "178.22.66.10",
+  "ipv6_address": "2a03:b0c0::1",
+  "memory": 536870912,
+  "name": "web",
+  "pending_restart": false,
+  "power_state": %q,
+  "shutdown_method": "stop",
+  "shutdown_timeout": 300,
+  "vnc_password": "secret"
+}`, powerState))
+		value, err := tftypes.ValueFromJSONWithOpts(raw, schemaResponse.Schema.Type().TerraformType(ctx),
+			tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+		assert.NoError(t, err)
+		return value
+	}
+
+	type testCase struct {
+		powerState   string
+		expectedIPv4 types.String
+		expectedIPv6 types.String
+	}
+	tests := map[string]testCase{
+		"stopped": {
+			powerState:   serverPowerStateStopped,
+			expectedIPv4: types.StringUnknown(),
+			expectedIPv6: types.StringUnknown(),
+		},
+		"unchanged": {
+			powerState:   serverPowerStateRunning,
+			expectedIPv4: types.StringValue("178.22.66.10"),
+			expectedIPv6: types.StringValue("2a03:b0c0::1"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Raw: rawValue(serverPowerStateRunning), Schema: schemaResponse.Schema}
+			plan := tfsdk.Plan{Raw: rawValue(test.powerState), Schema: schemaResponse.Schema}
+			response := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &response)
+			assert.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+
+			var data serverResourceModel
+			diags := response.Plan.Get(ctx, &data)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expectedIPv4, data.IPv4Address)
+			assert.Equal(t, test.expectedIPv6, data.IPv6Address)
+		})
+	}
+}
+
+func TestServerResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &serverResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	var schemaResponse resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+
+	rawState := []byte(`{
+  "cpu": 2000,
+  "drive": [{"device": "virtio", "uuid": "5b5bd4a9-1f11-4e29-8bbc-a1a5a8e0fd7e"}],
+  "enclave_page_caches": [],
+  "id": "2d3a2c8c-d8a8-4ec7-9d85-5e1c4c2ad7fb",
+  "ipv4_address": "178.22.66.10",
+  "memory": 536870912,
+  "meta": {},
+  "name": "web",
+  "network": [{"ipv4_address": "", "type": "dhcp", "vlan_uuid": ""}],
+  "resource_uri": "/api/2.0/servers/2d3a2c8c-d8a8-4ec7-9d85-5e1c4c2ad7fb/",
+  "smp": 1,
+  "ssh_keys": [],
+  "tags": [],
+  "timeouts": null,
+  "vnc_password": "secret"
+}`)
+	rawValue, err := tftypes.ValueFromJSONWithOpts(rawState, upgrader.PriorSchema.Type().TerraformType(ctx),
+		tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+	assert.NoError(t, err)
+
+	request := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Raw: rawValue, Schema: *upgrader.PriorSchema},
+	}
+	response := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResponse.Schema},
+	}
+	upgrader.StateUpgrader(ctx, request, &response)
+	assert.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+
+	var data serverResourceModel
+	diags := response.State.Get(ctx, &data)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, "2d3a2c8c-d8a8-4ec7-9d85-5e1c4c2ad7fb", data.ID.ValueString())
+	assert.Equal(t, int64(2000), data.CPU.ValueInt64())
+	assert.Equal(t, "running", data.PowerState.ValueString())
+	assert.Equal(t, "stop", data.ShutdownMethod.ValueString())
+	assert.Equal(t, int64(300), data.ShutdownTimeout.ValueInt64())
+
+	var drives []serverDriveModel
+	diags = data.Drives.ElementsAs(ctx, &drives, false)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []serverDriveModel{{
+		BootOrder:  types.Int64Value(1),
+		DevChannel: types.StringValue("0:1"),
+		Device:     types.StringValue("virtio"),
+		UUID:       types.StringValue("5b5bd4a9-1f11-4e29-8bbc-a1a5a8e0fd7e"),
+	}}, drives)
+	assert.True(t, data.BootDisk.IsNull())
+	assert.True(t, data.CPUType.IsNull())
+	assert.Equal(t, "none", data.DeleteDrives.ValueString())
+	assert.False(t, data.DeleteTags.ValueBool())
+	assert.False(t, data.CPUsInsteadOfCores.ValueBool())
+	assert.True(t, data.EnclavePageCaches.IsNull())
+	assert.False(t, data.HVRelaxed.ValueBool())
+	assert.True(t, data.Hypervisor.IsNull())
+	assert.True(t, data.IgnoreMetaKeys.IsNull())
+	assert.True(t, data.Meta.IsNull())
+	assert.True(t, data.NetworkConfig.IsNull())
+	assert.True(t, data.SSHKeys.IsNull())
+	assert.True(t, data.UserData.IsNull())
+	assert.True(t, data.TagNames.IsNull())
+	assert.Equal(t, 0, len(data.Tags.Elements()))
+	assert.Equal(t, 0, len(data.TagsAll.Elements()))
+
+	var networks []serverNetworkModel
+	diags = data.Networks.ElementsAs(ctx, &networks, false)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []serverNetworkModel{{
+		FirewallPolicy: types.StringNull(),
+		IPv4Address:    types.StringNull(),
+		IPv6:           types.ObjectNull(serverNetworkIPv6AttrTypes),
+		MAC:            types.StringNull(),
+		Model:          types.StringNull(),
+		RuntimeIPv4:    types.StringNull(),
+		Type:           types.StringValue("dhcp"),
+		VLANUUID:       types.StringNull(),
+	}}, networks)
+}
+
+func TestServerResource_expandEnclavePageCaches(t *testing.T) {
+	ctx := context.Background()
+
+	type testCase struct {
+		input    types.List
+		expected []cloudsigma.EnclavePageCache
+	}
+	tests := map[string]testCase{
+		"null": {
+			input:    types.ListNull(types.Int64Type),
+			expected: []cloudsigma.EnclavePageCache{},
+		},
+		"single_epc": {
+			input:    listOfInt64(1024),
+			expected: []cloudsigma.EnclavePageCache{{Size: 1024}},
+		},
+		"multiple_epcs": {
+			input:    listOfInt64(1024, 2048),
+			expected: []cloudsigma.EnclavePageCache{{Size: 1024}, {Size: 2048}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, diags := expandEnclavePageCaches(ctx, test.input)
+
+			assert.False(t, diags.HasError())
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestServerResource_flattenEnclavePageCaches(t *testing.T) {
+	ctx := context.Background()
+
+	type testCase struct {
+		input    []cloudsigma.EnclavePageCache
+		expected types.List
+	}
+	tests := map[string]testCase{
+		"nil": {
+			input:    nil,
+			expected: types.ListValueMust(types.Int64Type, []attr.Value{}),
+		},
+		"single_epc": {
+			input:    []cloudsigma.EnclavePageCache{{Size: 1024}},
+			expected: listOfInt64(1024),
+		},
+		"multiple_epcs": {
+			input:    []cloudsigma.EnclavePageCache{{Size: 1024}, {Size: 2048}},
+			expected: listOfInt64(1024, 2048),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, diags := flattenEnclavePageCaches(ctx, test.input)
+
+			assert.False(t, diags.HasError())
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestServerResource_flattenServerDrives(t *testing.T) {
+	ctx := context.Background()
+
+	serverDrives := []cloudsigma.ServerDrive{
+		{DevChannel: "0:3", Device: "ide", Drive: &cloudsigma.Drive{UUID: "cdrom-uuid"}},
+		{BootOrder: 2, DevChannel: "0:2", Device: "virtio", Drive: &cloudsigma.Drive{UUID: "data-uuid"}},
+		{BootOrder: 1, DevChannel: "0:1", Device: "virtio", Drive: &cloudsigma.Drive{UUID: "boot-uuid"}},
+	}
+
+	list, diags := flattenServerDrives(ctx, serverDrives)
+	assert.False(t, diags.HasError())
+
+	var drives []serverDriveModel
+	diags = list.ElementsAs(ctx, &drives, false)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, []serverDriveModel{
+		{
+			BootOrder:  types.Int64Value(1),
+			DevChannel: types.StringValue("0:1"),
+			Device:     types.StringValue("virtio"),
+			UUID:       types.StringValue("boot-uuid"),
+		},
+		{
+			BootOrder:  types.Int64Value(2),
+			DevChannel: types.StringValue("0:2"),
+			Device:     types.StringValue("virtio"),
+			UUID:       types.StringValue("data-uuid"),
+		},
+		{
+			BootOrder:  types.Int64Value(0),
+			DevChannel: types.StringValue("0:3"),
+			Device:     types.StringValue("ide"),
+			UUID:       types.StringValue("cdrom-uuid"),
+		},
+	}, drives)
+}
+
+func TestServerResource_expandServerDrives(t *testing.T) {
+	ctx := context.Background()
+
+	drives := types.ListValueMust(types.ObjectType{AttrTypes: serverDriveAttrTypes}, []attr.Value{
+		types.ObjectValueMust(serverDriveAttrTypes, map[string]attr.Value{
+			"boot_order":  types.Int64Value(1),
+			"dev_channel": types.StringValue("0:1"),
+			"device":      types.StringValue("virtio"),
+			"uuid":        types.StringValue("data-uuid"),
+		}),
+	})
+	bootDisk := types.ObjectValueMust(serverBootDiskAttrTypes, map[string]attr.Value{
+		"size":                 types.Int64Value(10737418240),
+		"source_library_drive": types.StringValue("library-uuid"),
+		"storage_type":         types.StringValue("dssd"),
+		"uuid":                 types.StringValue("boot-uuid"),
+	})
+
+	tests := map[string]struct {
+		data     serverResourceModel
+		expected []cloudsigma.ServerDrive
+	}{
+		"drives": {
+			data: serverResourceModel{BootDisk: types.ObjectNull(serverBootDiskAttrTypes), Drives: drives},
+			expected: []cloudsigma.ServerDrive{
+				{BootOrder: 1, DevChannel: "0:1", Device: "virtio", Drive: &cloudsigma.Drive{UUID: "data-uuid"}},
+			},
+		},
+		"boot disk": {
+			data: serverResourceModel{BootDisk: bootDisk, Drives: types.ListNull(types.ObjectType{AttrTypes: serverDriveAttrTypes})},
+			expected: []cloudsigma.ServerDrive{
+				{BootOrder: 1, DevChannel: "0:0", Device: "virtio", Drive: &cloudsigma.Drive{UUID: "boot-uuid"}},
+			},
+		},
+		"boot disk and drives": {
+			data: serverResourceModel{BootDisk: bootDisk, Drives: drives},
+			expected: []cloudsigma.ServerDrive{
+				{BootOrder: 1, DevChannel: "0:0", Device: "virtio", Drive: &cloudsigma.Drive{UUID: "boot-uuid"}},
+				{BootOrder: 2, DevChannel: "0:1", Device: "virtio", Drive: &cloudsigma.Drive{UUID: "data-uuid"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverDrives, diags := expandServerDrives(ctx, test.data)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expected, serverDrives)
+		})
+	}
+}
+
+func TestServerResource_flattenServerBootDisk(t *testing.T) {
+	prior := types.ObjectValueMust(serverBootDiskAttrTypes, map[string]attr.Value{
+		"size":                 types.Int64Unknown(),
+		"source_library_drive": types.StringValue("library-uuid"),
+		"storage_type":         types.StringUnknown(),
+		"uuid":                 types.StringUnknown(),
+	})
+
+	bootDisk := flattenServerBootDisk(&cloudsigma.Drive{Size: 10737418240, StorageType: "dssd", UUID: "boot-uuid"}, prior)
+	assert.Equal(t, types.ObjectValueMust(serverBootDiskAttrTypes, map[string]attr.Value{
+		"size":                 types.Int64Value(10737418240),
+		"source_library_drive": types.StringValue("library-uuid"),
+		"storage_type":         types.StringValue("dssd"),
+		"uuid":                 types.StringValue("boot-uuid"),
+	}), bootDisk)
+
+	assert.True(t, flattenServerBootDisk(nil, prior).IsNull())
+}
+
+func TestServerResource_resizeServerBootDisk(t *testing.T) {
+	var body string
+	client := newTestClient(&body, `{"uuid": "drive-uuid", "size": 21474836480}`)
+
+	// a drive cannot shrink, the requested size must not be silently ignored
+	err := resizeServerBootDisk(context.Background(), client, "drive-uuid", 10737418240)
+	assert.ErrorContains(t, err, "cannot be shrunk")
+
+	err = resizeServerBootDisk(context.Background(), client, "drive-uuid", 21474836480)
+	assert.NoError(t, err)
+}
+
+func TestServerResource_validateServerBootDiskSize(t *testing.T) {
+	libraryDrive := &cloudsigma.LibraryDrive{UUID: "library-drive-uuid", Size: 10737418240}
+
+	type testCase struct {
+		size        int64
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"smaller": {
+			size:        5368709120,
+			expectError: true,
+		},
+		"equal": {
+			size:        10737418240,
+			expectError: false,
+		},
+		"larger": {
+			size:        21474836480,
+			expectError: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bootDisk := serverBootDiskModel{Size: types.Int64Value(test.size)}
+			diags := validateServerBootDiskSize(bootDisk, libraryDrive)
+
+			assert.Equal(t, test.expectError, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestServerResource_serverBootDiskResized(t *testing.T) {
+	ctx := context.Background()
+
+	bootDisk := func(size types.Int64) types.Object {
+		return types.ObjectValueMust(serverBootDiskAttrTypes, map[string]attr.Value{
+			"size":                 size,
+			"source_library_drive": types.StringValue("library-uuid"),
+			"storage_type":         types.StringValue("dssd"),
+			"uuid":                 types.StringValue("boot-uuid"),
+		})
+	}
+
+	tests := map[string]struct {
+		plan     types.Object
+		state    types.Object
+		expected bool
+	}{
+		"unchanged": {
+			plan:     bootDisk(types.Int64Value(10737418240)),
+			state:    bootDisk(types.Int64Value(10737418240)),
+			expected: false,
+		},
+		"grown": {
+			plan:     bootDisk(types.Int64Value(16106127360)),
+			state:    bootDisk(types.Int64Value(10737418240)),
+			expected: true,
+		},
+		"unknown size": {
+			plan:     bootDisk(types.Int64Unknown()),
+			state:    bootDisk(types.Int64Value(10737418240)),
+			expected: false,
+		},
+		"added": {
+			plan:     bootDisk(types.Int64Value(10737418240)),
+			state:    types.ObjectNull(serverBootDiskAttrTypes),
+			expected: false,
+		},
+		"removed": {
+			plan:     types.ObjectNull(serverBootDiskAttrTypes),
+			state:    bootDisk(types.Int64Value(10737418240)),
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resized, diags := serverBootDiskResized(ctx, test.plan, test.state)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expected, resized)
+		})
+	}
+}
+
+func TestServerResource_serverDrivesNeedRestart(t *testing.T) {
+	ctx := context.Background()
+
+	drive := func(i int, device, uuid string) attr.Value {
+		return types.ObjectValueMust(serverDriveAttrTypes, map[string]attr.Value{
+			"boot_order":  types.Int64Value(int64(serverDriveBootOrder(i))),
+			"dev_channel": types.StringValue(serverDriveDevChannel(i)),
+			"device":      types.StringValue(device),
+			"uuid":        types.StringValue(uuid),
+		})
+	}
+	drives := func(values ...attr.Value) types.List {
+		return types.ListValueMust(types.ObjectType{AttrTypes: serverDriveAttrTypes}, values)
+	}
+	nullDrives := types.ListNull(types.ObjectType{AttrTypes: serverDriveAttrTypes})
+
+	type testCase struct {
+		plan        types.List
+		state       types.List
+		hasBootDisk bool
+		expected    bool
+	}
+	tests := map[string]testCase{
+		"unchanged": {
+			plan:     drives(drive(0, "virtio", "boot")),
+			state:    drives(drive(0, "virtio", "boot")),
+			expected: false,
+		},
+		"append_virtio": {
+			plan:     drives(drive(0, "virtio", "boot"), drive(1, "virtio", "data")),
+			state:    drives(drive(0, "virtio", "boot")),
+			expected: false,
+		},
+		"append_scsi": {
+			plan:     drives(drive(0, "virtio", "boot"), drive(1, "scsi", "data")),
+			state:    drives(drive(0, "virtio", "boot")),
+			expected: false,
+		},
+		"append_ide": {
+			plan:     drives(drive(0, "virtio", "boot"), drive(1, "ide", "data")),
+			state:    drives(drive(0, "virtio", "boot")),
+			expected: true,
+		},
+		"remove_last_virtio": {
+			plan:     drives(drive(0, "virtio", "boot")),
+			state:    drives(drive(0, "virtio", "boot"), drive(1, "virtio", "data")),
+			expected: false,
+		},
+		"remove_middle": {
+			plan:     drives(drive(0, "virtio", "boot"), drive(1, "virtio", "data2")),
+			state:    drives(drive(0, "virtio", "boot"), drive(1, "virtio", "data1"), drive(2, "virtio", "data2")),
+			expected: true,
+		},
+		"change_boot_drive": {
+			plan:     drives(drive(0, "virtio", "other")),
+			state:    drives(drive(0, "virtio", "boot")),
+			expected: true,
+		},
+		"add_boot_drive": {
+			plan:     drives(drive(0, "virtio", "boot")),
+			state:    nullDrives,
+			expected: true,
+		},
+		"remove_all_drives": {
+			plan:     nullDrives,
+			state:    drives(drive(0, "virtio", "boot")),
+			expected: true,
+		},
+		"unknown": {
+			plan:     types.ListUnknown(types.ObjectType{AttrTypes: serverDriveAttrTypes}),
+			state:    drives(drive(0, "virtio", "boot")),
+			expected: true,
+		},
+		"boot_disk_add_first_virtio": {
+			plan:        drives(drive(1, "virtio", "data")),
+			state:       types.ListValueMust(types.ObjectType{AttrTypes: serverDriveAttrTypes}, []attr.Value{}),
+			hasBootDisk: true,
+			expected:    false,
+		},
+		"boot_disk_remove_last_virtio": {
+			plan:        nullDrives,
+			state:       drives(drive(1, "virtio", "data")),
+			hasBootDisk: true,
+			expected:    false,
+		},
+		"boot_disk_add_first_ide": {
+			plan:        drives(drive(1, "ide", "data")),
+			state:       nullDrives,
+			hasBootDisk: true,
+			expected:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			needRestart, diags := serverDrivesNeedRestart(ctx, test.plan, test.state, test.hasBootDisk)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expected, needRestart)
+		})
+	}
+}
+
+func TestServerResource_serverDriveBootOrderFromPosition(t *testing.T) {
+	ctx := context.Background()
+
+	request := planmodifier.Int64Request{
+		Path:      path.Root("drive").AtListIndex(2).AtName("boot_order"),
+		PlanValue: types.Int64Unknown(),
+	}
+	response := planmodifier.Int64Response{PlanValue: request.PlanValue}
+	serverDriveBootOrderFromPosition().PlanModifyInt64(ctx, request, &response)
+	assert.Equal(t, types.Int64Value(3), response.PlanValue)
+
+	stringRequest := planmodifier.StringRequest{
+		Path:      path.Root("drive").AtListIndex(2).AtName("dev_channel"),
+		PlanValue: types.StringUnknown(),
+	}
+	stringResponse := planmodifier.StringResponse{PlanValue: stringRequest.PlanValue}
+	serverDriveDevChannelFromPosition().PlanModifyString(ctx, stringRequest, &stringResponse)
+	assert.Equal(t, types.StringValue("0:3"), stringResponse.PlanValue)
+}
+
+func TestServerResource_expandServerMeta(t *testing.T) {
+	ctx := context.Background()
+
+	type testCase struct {
+		data     serverResourceModel
+		expected map[string]interface{}
+	}
+	tests := map[string]testCase{
+		"null": {
+			data: serverResourceModel{
+				Meta:          types.MapNull(types.StringType),
+				NetworkConfig: types.StringNull(),
+				UserData:      types.StringNull(),
+			},
+			expected: map[string]interface{}{},
+		},
+		"meta_only": {
+			data: serverResourceModel{
+				Meta:          types.MapValueMust(types.StringType, map[string]attr.Value{"key": types.StringValue("value")}),
+				NetworkConfig: types.StringNull(),
+				UserData:      types.StringNull(),
+			},
+			expected: map[string]interface{}{"key": "value"},
+		},
+		"user_data": {
+			data: serverResourceModel{
+				Meta:          types.MapNull(types.StringType),
+				NetworkConfig: types.StringNull(),
+				UserData:      types.StringValue("#cloud-config"),
+			},
+			expected: map[string]interface{}{
+				"base64_fields":       "cloudinit-user-data",
+				"cloudinit-user-data": "I2Nsb3VkLWNvbmZpZw==",
+			},
+		},
+		"user_data_and_network_config": {
+			data: serverResourceModel{
+				Meta:          types.MapValueMust(types.StringType, map[string]attr.Value{"key": types.StringValue("value")}),
+				NetworkConfig: types.StringValue("version: 2"),
+				UserData:      types.StringValue("#cloud-config"),
+			},
+			expected: map[string]interface{}{
+				"base64_fields":            "cloudinit-network-config,cloudinit-user-data",
+				"cloudinit-network-config": "dmVyc2lvbjogMg==",
+				"cloudinit-user-data":      "I2Nsb3VkLWNvbmZpZw==",
+				"key":                      "value",
+			},
+		},
+		"user_data_and_base64_fields": {
+			data: serverResourceModel{
+				Meta: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"base64_fields": types.StringValue("key"),
+					"key":           types.StringValue("dmFsdWU="),
+				}),
+				NetworkConfig: types.StringNull(),
+				UserData:      types.StringValue("#cloud-config"),
+			},
+			expected: map[string]interface{}{
+				"base64_fields":       "key,cloudinit-user-data",
+				"cloudinit-user-data": "I2Nsb3VkLWNvbmZpZw==",
+				"key":                 "dmFsdWU=",
+			},
+		},
+		"deprecated_meta_keys": {
+			data: serverResourceModel{
+				Meta: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"base64_fields":       types.StringValue("cloudinit-user-data"),
+					"cloudinit-user-data": types.StringValue("I2Nsb3VkLWNvbmZpZw=="),
+				}),
+				NetworkConfig: types.StringNull(),
+				UserData:      types.StringNull(),
+			},
+			expected: map[string]interface{}{
+				"base64_fields":       "cloudinit-user-data",
+				"cloudinit-user-data": "I2Nsb3VkLWNvbmZpZw==",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			meta, diags := expandServerMeta(ctx, test.data)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expected, meta)
+		})
+	}
+}
+
+func TestServerResource_keepServerMetaKeys(t *testing.T) {
+	meta := map[string]interface{}{"key": "value"}
+	current := map[string]interface{}{
+		"agent-key": "agent-value",
+		"key":       "old-value",
+		"other-key": "other-value",
+	}
+
+	keepServerMetaKeys(meta, current, []string{"agent-key", "missing-key"})
+	assert.Equal(t, map[string]interface{}{
+		"agent-key": "agent-value",
+		"key":       "value",
+	}, meta)
+}
+
+func TestServerResource_validateServerMetaKeys(t *testing.T) {
+	ctx := context.Background()
+	meta := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"agent-key": types.StringValue("value"),
+		"key":       types.StringValue("value"),
+	})
+
+	diags := validateServerMetaKeys(ctx, meta, []string{"other-key"})
+	assert.False(t, diags.HasError(), diags)
+
+	diags = validateServerMetaKeys(ctx, meta, []string{"agent-key"})
+	assert.Equal(t, []path.Path{path.Root("meta").AtMapKey("agent-key")}, diagnosticPaths(diags))
+
+	diags = validateServerMetaKeys(ctx, types.MapNull(types.StringType), []string{"agent-key"})
+	assert.False(t, diags.HasError(), diags)
+}
+
+func TestServerResource_validateServerMetaCloudInitKeys(t *testing.T) {
+	ctx := context.Background()
+	meta := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"base64_fields":       types.StringValue("cloudinit-user-data"),
+		"cloudinit-user-data": types.StringValue("I2Nsb3VkLWNvbmZpZw=="),
+		"key":                 types.StringValue("value"),
+	})
+
+	diags := validateServerMetaCloudInitKeys(ctx, serverResourceModel{
+		Meta:          meta,
+		NetworkConfig: types.StringNull(),
+		UserData:      types.StringNull(),
+	})
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 2, diags.WarningsCount())
+
+	diags = validateServerMetaCloudInitKeys(ctx, serverResourceModel{
+		Meta:          meta,
+		NetworkConfig: types.StringNull(),
+		UserData:      types.StringValue("#cloud-config"),
+	})
+	assert.Equal(t, 1, diags.ErrorsCount())
+	assert.Equal(t, []path.Path{path.Root("meta").AtMapKey("cloudinit-user-data")}, diagnosticPaths(diags.Errors()))
+
+	diags = validateServerMetaCloudInitKeys(ctx, serverResourceModel{
+		Meta:          types.MapNull(types.StringType),
+		NetworkConfig: types.StringNull(),
+		UserData:      types.StringValue("#cloud-config"),
+	})
+	assert.Empty(t, diags)
+}
+
+func TestServerResource_serverIgnoreMetaKeys(t *testing.T) {
+	ctx := context.Background()
+	r := &serverResource{ignoreMetaKeys: []string{"provider-key", "shared-key"}}
+
+	ignoreMetaKeys, diags := r.serverIgnoreMetaKeys(ctx, serverResourceModel{
+		IgnoreMetaKeys: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("resource-key"),
+			types.StringValue("shared-key"),
+		}),
+	})
+	assert.False(t, diags.HasError(), diags)
+	assert.ElementsMatch(t, []string{"provider-key", "resource-key", "shared-key"}, ignoreMetaKeys)
+	assert.Equal(t, []string{"provider-key", "shared-key"}, r.ignoreMetaKeys)
+
+	ignoreMetaKeys, diags = r.serverIgnoreMetaKeys(ctx, serverResourceModel{IgnoreMetaKeys: types.SetNull(types.StringType)})
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"provider-key", "shared-key"}, ignoreMetaKeys)
+}
+
+func TestServerResource_flattenServerMeta(t *testing.T) {
+	meta := map[string]interface{}{
+		"base64_fields":            "cloudinit-user-data",
+		"cloudinit-network-config": "version: 2",
+		"cloudinit-user-data":      "I2Nsb3VkLWNvbmZpZw==",
+		"key":                      "value",
+		"ssh_public_key":           "ssh-ed25519 AAAA",
+	}
+
+	assert.Equal(t, map[string]string{"key": "value"}, flattenServerMeta(meta, nil, nil))
+	assert.Equal(t, map[string]string{}, flattenServerMeta(meta, []string{"key"}, nil))
+	assert.Equal(t, map[string]string{
+		"base64_fields":       "cloudinit-user-data",
+		"cloudinit-user-data": "I2Nsb3VkLWNvbmZpZw==",
+		"key":                 "value",
+	}, flattenServerMeta(meta, nil, []string{"base64_fields", "cloudinit-user-data", "key"}))
+
+	// base64_fields of the meta attribute without the key of network_config
+	merged := map[string]interface{}{
+		"base64_fields":            "key,cloudinit-network-config",
+		"cloudinit-network-config": "dmVyc2lvbjogMg==",
+		"key":                      "dmFsdWU=",
+	}
+	assert.Equal(t, map[string]string{
+		"base64_fields": "key",
+		"key":           "dmFsdWU=",
+	}, flattenServerMeta(merged, nil, []string{"base64_fields", "key"}))
+
+	userData, ok := flattenServerMetaField(meta, serverMetaUserData)
+	assert.True(t, ok)
+	assert.Equal(t, "#cloud-config", userData)
+
+	networkConfig, ok := flattenServerMetaField(meta, serverMetaNetworkConfig)
+	assert.True(t, ok)
+	assert.Equal(t, "version: 2", networkConfig)
+
+	_, ok = flattenServerMetaField(map[string]interface{}{}, serverMetaUserData)
+	assert.False(t, ok)
+}
+
+func TestServerResource_expandServerNICIPv6(t *testing.T) {
+	ctx := context.Background()
+
+	ipv6 := func(ipv6Type, address string) types.Object {
+		return types.ObjectValueMust(serverNetworkIPv6AttrTypes, map[string]attr.Value{
+			"address": stringValueOrNull(address),
+			"type":    types.StringValue(ipv6Type),
+		})
+	}
+
+	type testCase struct {
+		input       types.Object
+		expected    *cloudsigma.ServerIPConfiguration
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"null": {
+			input:    types.ObjectNull(serverNetworkIPv6AttrTypes),
+			expected: nil,
+		},
+		"none": {
+			input:    ipv6("none", ""),
+			expected: nil,
+		},
+		"dhcp": {
+			input:    ipv6("dhcp", ""),
+			expected: &cloudsigma.ServerIPConfiguration{Type: "dhcp"},
+		},
+		"static": {
+			input: ipv6("static", "2a03:b0c0::1"),
+			expected: &cloudsigma.ServerIPConfiguration{
+				Type:      "static",
+				IPAddress: &cloudsigma.IP{UUID: "2a03:b0c0::1"},
+			},
+		},
+		"static_without_address": {
+			input:       ipv6("static", ""),
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ip6Configuration, diags := expandServerNICIPv6(ctx, test.input)
+
+			assert.Equal(t, test.expectError, diags.HasError(), diags)
+			assert.Equal(t, test.expected, ip6Configuration)
+		})
+	}
+}
+
+func TestServerResource_flattenServerNICIPv6(t *testing.T) {
+	none := types.ObjectValueMust(serverNetworkIPv6AttrTypes, map[string]attr.Value{
+		"address": types.StringNull(),
+		"type":    types.StringValue("none"),
+	})
+
+	assert.Equal(t, none, flattenServerNICIPv6(cloudsigma.ServerNIC{}, none))
+	assert.Equal(t, types.ObjectNull(serverNetworkIPv6AttrTypes),
+		flattenServerNICIPv6(cloudsigma.ServerNIC{}, types.ObjectNull(serverNetworkIPv6AttrTypes)))
+}
+
+func TestServerResource_flattenServerNICs(t *testing.T) {
+	ctx := context.Background()
+
+	srv := &cloudsigma.Server{
+		NICs: []cloudsigma.ServerNIC{
+			{
+				IP4Configuration: &cloudsigma.ServerIPConfiguration{Type: "dhcp"},
+				IP6Configuration: &cloudsigma.ServerIPConfiguration{Type: "dhcp"},
+				MACAddress:       "22:aa:bb:cc:dd:01",
+				Model:            "virtio",
+			},
+			{
+				FirewallPolicy: &cloudsigma.FirewallPolicy{UUID: "firewall-policy-uuid"},
+				MACAddress:     "22:aa:bb:cc:dd:02",
+				Model:          "e1000",
+				VLAN:           &cloudsigma.VLAN{UUID: "vlan-uuid"},
+			},
+		},
+	}
+	runtime := &server.Runtime{
+		NICs: []server.RuntimeNIC{
+			{InterfaceType: "private", MAC: "22:aa:bb:cc:dd:02"},
+			{InterfaceType: "public", IPv4: &cloudsigma.ResourceLink{UUID: "178.33.44.55"}, MAC: "22:aa:bb:cc:dd:01"},
+		},
+	}
+	dhcp := serverNetworkModel{
+		FirewallPolicy: types.StringNull(),
+		IPv4Address:    types.StringNull(),
+		IPv6: types.ObjectValueMust(serverNetworkIPv6AttrTypes, map[string]attr.Value{
+			"address": types.StringNull(),
+			"type":    types.StringValue("dhcp"),
+		}),
+		MAC:         types.StringValue("22:aa:bb:cc:dd:01"),
+		Model:       types.StringValue("virtio"),
+		RuntimeIPv4: types.StringValue("178.33.44.55"),
+		Type:        types.StringValue("dhcp"),
+		VLANUUID:    types.StringNull(),
+	}
+	vlan := serverNetworkModel{
+		FirewallPolicy: types.StringValue("firewall-policy-uuid"),
+		IPv4Address:    types.StringNull(),
+		IPv6:           types.ObjectNull(serverNetworkIPv6AttrTypes),
+		MAC:            types.StringValue("22:aa:bb:cc:dd:02"),
+		Model:          types.StringValue("e1000"),
+		RuntimeIPv4:    types.StringNull(),
+		Type:           types.StringNull(),
+		VLANUUID:       types.StringValue("vlan-uuid"),
+	}
+	networkType := types.ObjectType{AttrTypes: serverNetworkAttrTypes}
+
+	type testCase struct {
+		prior    types.List
+		expected []serverNetworkModel
+	}
+	tests := map[string]testCase{
+		"without_prior": {
+			prior:    types.ListNull(networkType),
+			expected: []serverNetworkModel{dhcp, vlan},
+		},
+		"prior_order": {
+			prior:    types.ListValueMust(networkType, []attr.Value{serverNetworkObject(vlan), serverNetworkObject(dhcp)}),
+			expected: []serverNetworkModel{vlan, dhcp},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			list, diags := flattenServerNICs(ctx, srv, runtime, test.prior)
+			assert.False(t, diags.HasError(), diags)
+
+			var networks []serverNetworkModel
+			diags = list.ElementsAs(ctx, &networks, false)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expected, networks)
+		})
+	}
+}
+
+func TestServerResource_flattenServerRuntimeNICs(t *testing.T) {
+	ctx := context.Background()
+
+	srv := &cloudsigma.Server{
+		NICs: []cloudsigma.ServerNIC{
+			{MACAddress: "22:aa:bb:cc:dd:01"},
+			{MACAddress: "22:aa:bb:cc:dd:02", VLAN: &cloudsigma.VLAN{UUID: "vlan-uuid"}},
+		},
+	}
+	runtime := &server.Runtime{
+		NICs: []server.RuntimeNIC{
+			{
+				InterfaceType: "public",
+				IO:            &server.RuntimeNICIO{BytesRecv: 100, BytesSent: 200, PacketsRecv: 1, PacketsSent: 2},
+				IPv4:          &cloudsigma.ResourceLink{UUID: "178.33.44.55"},
+				MAC:           "22:aa:bb:cc:dd:01",
+			},
+			{
+				InterfaceType: "private",
+				MAC:           "22:aa:bb:cc:dd:02",
+			},
+		},
+	}
+
+	list, diags := flattenServerRuntimeNICs(ctx, srv, runtime)
+	assert.False(t, diags.HasError(), diags)
+
+	var nics []serverRuntimeNICModel
+	diags = list.ElementsAs(ctx, &nics, false)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []serverRuntimeNICModel{
+		{
+			InterfaceType: types.StringValue("public"),
+			IO: types.ObjectValueMust(serverRuntimeNICIOAttrTypes, map[string]attr.Value{
+				"bytes_recv":   types.Int64Value(100),
+				"bytes_sent":   types.Int64Value(200),
+				"packets_recv": types.Int64Value(1),
+				"packets_sent": types.Int64Value(2),
+			}),
+			IPv4Address: types.StringValue("178.33.44.55"),
+			IPv6Address: types.StringNull(),
+			MAC:         types.StringValue("22:aa:bb:cc:dd:01"),
+			VLANUUID:    types.StringNull(),
+		},
+		{
+			InterfaceType: types.StringValue("private"),
+			IO:            types.ObjectNull(serverRuntimeNICIOAttrTypes),
+			IPv4Address:   types.StringNull(),
+			IPv6Address:   types.StringNull(),
+			MAC:           types.StringValue("22:aa:bb:cc:dd:02"),
+			VLANUUID:      types.StringValue("vlan-uuid"),
+		},
+	}, nics)
+
+	list, diags = flattenServerRuntimeNICs(ctx, srv, nil)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 0, len(list.Elements()))
+}
+
+func TestServerResource_planServerNetworks(t *testing.T) {
+	ctx := context.Background()
+	networkType := types.ObjectType{AttrTypes: serverNetworkAttrTypes}
+
+	dhcp := serverNetworkModel{
+		FirewallPolicy: types.StringNull(),
+		IPv4Address:    types.StringNull(),
+		IPv6:           types.ObjectNull(serverNetworkIPv6AttrTypes),
+		MAC:            types.StringValue("22:aa:bb:cc:dd:01"),
+		Model:          types.StringValue("virtio"),
+		RuntimeIPv4:    types.StringValue("178.33.44.55"),
+		Type:           types.StringValue("dhcp"),
+		VLANUUID:       types.StringNull(),
+	}
+	vlan := serverNetworkModel{
+		FirewallPolicy: types.StringNull(),
+		IPv4Address:    types.StringNull(),
+		IPv6:           types.ObjectNull(serverNetworkIPv6AttrTypes),
+		MAC:            types.StringValue("22:aa:bb:cc:dd:02"),
+		Model:          types.StringValue("virtio"),
+		RuntimeIPv4:    types.StringNull(),
+		Type:           types.StringNull(),
+		VLANUUID:       types.StringValue("vlan-uuid"),
+	}
+	planned := func(network serverNetworkModel) serverNetworkModel {
+		network.MAC = types.StringUnknown()
+		network.Model = types.StringUnknown()
+		network.RuntimeIPv4 = types.StringUnknown()
+		return network
+	}
+	state := types.ListValueMust(networkType, []attr.Value{serverNetworkObject(dhcp), serverNetworkObject(vlan)})
+
+	// reordered cards keep their MAC addresses and are not a change
+	plan := types.ListValueMust(networkType, []attr.Value{serverNetworkObject(planned(vlan)), serverNetworkObject(planned(dhcp))})
+	changed, diags := serverNetworksChanged(ctx, plan, state)
+	assert.False(t, diags.HasError(), diags)
+	assert.False(t, changed)
+
+	list, diags := planServerNetworks(ctx, plan, state, false)
+	assert.False(t, diags.HasError(), diags)
+	var networks []serverNetworkModel
+	diags = list.ElementsAs(ctx, &networks, false)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, dhcp.MAC, networks[1].MAC)
+	assert.Equal(t, vlan.MAC, networks[0].MAC)
+	assert.True(t, networks[1].RuntimeIPv4.IsUnknown())
+
+	// unchanged cards keep their runtime addresses
+	plan = types.ListValueMust(networkType, []attr.Value{serverNetworkObject(planned(dhcp)), serverNetworkObject(planned(vlan))})
+	changed, diags = serverNetworksChanged(ctx, plan, state)
+	assert.False(t, diags.HasError(), diags)
+	assert.False(t, changed)
+
+	list, diags = planServerNetworks(ctx, plan, state, true)
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, list.Equal(state))
+}
+
+func TestServerResource_serverNetworksChanged(t *testing.T) {
+	ctx := context.Background()
+	networkType := types.ObjectType{AttrTypes: serverNetworkAttrTypes}
+
+	network := func(mac, vlanUUID string) serverNetworkModel {
+		return serverNetworkModel{
+			FirewallPolicy: types.StringNull(),
+			IPv4Address:    types.StringNull(),
+			IPv6:           types.ObjectNull(serverNetworkIPv6AttrTypes),
+			MAC:            types.StringValue(mac),
+			Model:          types.StringValue("virtio"),
+			RuntimeIPv4:    types.StringNull(),
+			Type:           types.StringNull(),
+			VLANUUID:       types.StringValue(vlanUUID),
+		}
+	}
+	networks := func(values ...serverNetworkModel) types.List {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, serverNetworkObject(value))
+		}
+		return types.ListValueMust(networkType, elements)
+	}
+	first := network("22:aa:bb:cc:dd:01", "vlan-1")
+	second := network("22:aa:bb:cc:dd:02", "vlan-2")
+
+	type testCase struct {
+		plan     types.List
+		expected bool
+	}
+	tests := map[string]testCase{
+		"unchanged": {
+			plan:     networks(first, second),
+			expected: false,
+		},
+		"reordered_fixed_mac": {
+			plan:     networks(second, first),
+			expected: false,
+		},
+		"changed_vlan": {
+			plan:     networks(second, network("22:aa:bb:cc:dd:01", "vlan-3")),
+			expected: true,
+		},
+		"changed_mac": {
+			plan:     networks(second, network("22:aa:bb:cc:dd:03", "vlan-1")),
+			expected: true,
+		},
+		"removed": {
+			plan:     networks(second),
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			changed, diags := serverNetworksChanged(ctx, test.plan, networks(first, second))
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expected, changed)
+		})
+	}
+}
+
+func serverNetworkObject(network serverNetworkModel) attr.Value {
+	return types.ObjectValueMust(serverNetworkAttrTypes, map[string]attr.Value{
+		"firewall_policy": network.FirewallPolicy,
+		"ipv4_address":    network.IPv4Address,
+		"ipv6":            network.IPv6,
+		"mac":             network.MAC,
+		"model":           network.Model,
+		"runtime_ipv4":    network.RuntimeIPv4,
+		"type":            network.Type,
+		"vlan_uuid":       network.VLANUUID,
+	})
+}
+
+func TestServerResource_validateServerCapabilities(t *testing.T) {
+	capabilities := &cloudsigma.Capabilities{
+		Hosts: &cloudsigma.CapabilitiesHosts{
+			AMD: &cloudsigma.CapabilitiesHost{
+				CPU:       &cloudsigma.CapabilitiesLimitation{Min: 250, Max: 80000},
+				CPUPerSMP: &cloudsigma.CapabilitiesLimitation{Min: 1000, Max: 2500},
+				Memory:    &cloudsigma.CapabilitiesLimitation{Min: 268435456, Max: 137438953472},
+				SMP:       &cloudsigma.CapabilitiesLimitation{Min: 1, Max: 32},
+			},
+		},
+		Hypervisors: &cloudsigma.CapabilitiesHypervisors{KVM: []string{"amd"}},
+	}
+	serverData := func(cpuType, hypervisor string, cpu, memory, smp int64) serverResourceModel {
+		return serverResourceModel{
+			CPU:        types.Int64Value(cpu),
+			CPUType:    stringValueOrNull(cpuType),
+			Hypervisor: stringValueOrNull(hypervisor),
+			Memory:     types.Int64Value(memory),
+			SMP:        types.Int64Value(smp),
+		}
+	}
+
+	type testCase struct {
+		data     serverResourceModel
+		expected []path.Path
+	}
+	tests := map[string]testCase{
+		"valid": {
+			data: serverData("amd", "kvm", 4000, 2147483648, 2),
+		},
+		"without_cpu_type": {
+			data: serverData("", "", 200000, 1024, 0),
+		},
+		"unavailable_cpu_type": {
+			data:     serverData("intel", "", 2000, 2147483648, 1),
+			expected: []path.Path{path.Root("cpu_type")},
+		},
+		"unsupported_by_hypervisor": {
+			data:     serverData("intel", "kvm", 2000, 2147483648, 1),
+			expected: []path.Path{path.Root("cpu_type"), path.Root("cpu_type")},
+		},
+		"out_of_limits": {
+			data:     serverData("amd", "", 100000, 1024, 64),
+			expected: []path.Path{path.Root("cpu"), path.Root("memory"), path.Root("smp")},
+		},
+		"cpu_per_smp": {
+			data:     serverData("amd", "", 8000, 2147483648, 2),
+			expected: []path.Path{path.Root("cpu")},
+		},
+		"unknown_smp": {
+			data: serverResourceModel{
+				CPU:     types.Int64Value(2000),
+				CPUType: types.StringValue("amd"),
+				Memory:  types.Int64Value(2147483648),
+				SMP:     types.Int64Unknown(),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := validateServerCapabilities(capabilities, test.data)
+
+			assert.Equal(t, test.expected, diagnosticPaths(diags))
+		})
+	}
+}
+
+func TestServerResource_validateServerSMP(t *testing.T) {
+	type testCase struct {
+		data     serverResourceModel
+		expected []path.Path
+	}
+	tests := map[string]testCase{
+		"without_smp": {
+			data: serverResourceModel{CPU: types.Int64Value(2000), SMP: types.Int64Null()},
+		},
+		"valid": {
+			data: serverResourceModel{CPU: types.Int64Value(2000), SMP: types.Int64Value(2)},
+		},
+		"unknown_cpu": {
+			data: serverResourceModel{CPU: types.Int64Unknown(), SMP: types.Int64Value(8)},
+		},
+		"below_minimum": {
+			data:     serverResourceModel{CPU: types.Int64Value(2000), SMP: types.Int64Value(5)},
+			expected: []path.Path{path.Root("smp")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := validateServerSMP(test.data)
+
+			assert.Equal(t, test.expected, diagnosticPaths(diags))
+		})
+	}
+}
+
+func TestServerResource_validateServerNetworks(t *testing.T) {
+	ctx := context.Background()
+
+	network := func(networkType, address, vlan string, ipv6 types.Object) attr.Value {
+		return serverNetworkObject(serverNetworkModel{
+			FirewallPolicy: types.StringNull(),
+			IPv4Address:    stringValueOrNull(address),
+			IPv6:           ipv6,
+			MAC:            types.StringNull(),
+			Model:          types.StringNull(),
+			RuntimeIPv4:    types.StringNull(),
+			Type:           stringValueOrNull(networkType),
+			VLANUUID:       stringValueOrNull(vlan),
+		})
+	}
+	ipv6 := func(ipv6Type string) types.Object {
+		return types.ObjectValueMust(serverNetworkIPv6AttrTypes, map[string]attr.Value{
+			"address": types.StringNull(),
+			"type":    types.StringValue(ipv6Type),
+		})
+	}
+	noIPv6 := types.ObjectNull(serverNetworkIPv6AttrTypes)
+
+	type testCase struct {
+		networks []attr.Value
+		expected []path.Path
+	}
+	tests := map[string]testCase{
+		"valid": {
+			networks: []attr.Value{
+				network("dhcp", "", "", ipv6("dhcp")),
+				network("", "", "vlan-uuid", ipv6("none")),
+			},
+		},
+		"static_without_address": {
+			networks: []attr.Value{
+				network("dhcp", "", "", noIPv6),
+				network("static", "", "", noIPv6),
+			},
+			expected: []path.Path{path.Root("network").AtListIndex(1).AtName("ipv4_address")},
+		},
+		"static_with_unknown_address": {
+			networks: []attr.Value{
+				serverNetworkObject(serverNetworkModel{
+					FirewallPolicy: types.StringNull(),
+					IPv4Address:    types.StringUnknown(),
+					IPv6:           noIPv6,
+					MAC:            types.StringNull(),
+					Model:          types.StringNull(),
+					RuntimeIPv4:    types.StringNull(),
+					Type:           types.StringValue("static"),
+					VLANUUID:       types.StringNull(),
+				}),
+			},
+		},
+		"unknown_type_and_vlan": {
+			networks: []attr.Value{
+				serverNetworkObject(serverNetworkModel{
+					FirewallPolicy: types.StringNull(),
+					IPv4Address:    types.StringNull(),
+					IPv6:           noIPv6,
+					MAC:            types.StringNull(),
+					Model:          types.StringNull(),
+					RuntimeIPv4:    types.StringNull(),
+					Type:           types.StringUnknown(),
+					VLANUUID:       types.StringValue("vlan-uuid"),
+				}),
+			},
+		},
+		"type_and_vlan": {
+			networks: []attr.Value{network("dhcp", "", "vlan-uuid", noIPv6)},
+			expected: []path.Path{path.Root("network").AtListIndex(0).AtName("vlan_uuid")},
+		},
+		"ipv6_static_without_address": {
+			networks: []attr.Value{network("dhcp", "", "", ipv6("static"))},
+			expected: []path.Path{path.Root("network").AtListIndex(0).AtName("ipv6").AtName("address")},
+		},
+		"ipv6_and_vlan": {
+			networks: []attr.Value{network("", "", "vlan-uuid", ipv6("dhcp"))},
+			expected: []path.Path{path.Root("network").AtListIndex(0).AtName("ipv6")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			list := types.ListValueMust(types.ObjectType{AttrTypes: serverNetworkAttrTypes}, test.networks)
+
+			diags := validateServerNetworks(ctx, list)
+
+			assert.Equal(t, test.expected, diagnosticPaths(diags))
+		})
+	}
+}
+
+func TestServerResource_validateServerDrives(t *testing.T) {
+	ctx := context.Background()
+
+	drive := func(device string, uuid types.String) attr.Value {
+		return types.ObjectValueMust(serverDriveAttrTypes, map[string]attr.Value{
+			"boot_order":  types.Int64Null(),
+			"dev_channel": types.StringNull(),
+			"device":      stringValueOrNull(device),
+			"uuid":        uuid,
+		})
+	}
+	driveWithBootOrder := func(bootOrder int64, uuid string) attr.Value {
+		return types.ObjectValueMust(serverDriveAttrTypes, map[string]attr.Value{
+			"boot_order":  types.Int64Value(bootOrder),
+			"dev_channel": types.StringNull(),
+			"device":      types.StringValue("virtio"),
+			"uuid":        types.StringValue(uuid),
+		})
+	}
+
+	type testCase struct {
+		drives   []attr.Value
+		expected []path.Path
+	}
+	tests := map[string]testCase{
+		"valid": {
+			drives: []attr.Value{
+				drive("ide", types.StringValue("boot-uuid")),
+				drive("", types.StringValue("data-uuid")),
+				drive("scsi", types.StringUnknown()),
+				drive("virtio", types.StringUnknown()),
+			},
+		},
+		"ide_data_drive": {
+			drives: []attr.Value{
+				drive("virtio", types.StringValue("boot-uuid")),
+				drive("ide", types.StringValue("data-uuid")),
+			},
+		},
+		"invalid_device": {
+			drives: []attr.Value{
+				drive("virtio", types.StringValue("boot-uuid")),
+				drive("sata", types.StringValue("data-uuid")),
+			},
+			expected: []path.Path{path.Root("drive").AtListIndex(1).AtName("device")},
+		},
+		"valid_boot_order": {
+			drives: []attr.Value{
+				driveWithBootOrder(1, "boot-uuid"),
+				driveWithBootOrder(2, "data-uuid"),
+			},
+		},
+		"non_positive_boot_order": {
+			drives: []attr.Value{
+				driveWithBootOrder(0, "boot-uuid"),
+				driveWithBootOrder(-1, "data-uuid"),
+			},
+			expected: []path.Path{
+				path.Root("drive").AtListIndex(0).AtName("boot_order"),
+				path.Root("drive").AtListIndex(1).AtName("boot_order"),
+			},
+		},
+		"duplicate_boot_order": {
+			drives: []attr.Value{
+				driveWithBootOrder(1, "boot-uuid"),
+				driveWithBootOrder(2, "data-uuid"),
+				driveWithBootOrder(1, "other-uuid"),
+			},
+			expected: []path.Path{path.Root("drive").AtListIndex(2).AtName("boot_order")},
+		},
+		"duplicate_uuid": {
+			drives: []attr.Value{
+				drive("virtio", types.StringValue("boot-uuid")),
+				drive("virtio", types.StringValue("data-uuid")),
+				drive("virtio", types.StringValue("boot-uuid")),
+			},
+			expected: []path.Path{path.Root("drive").AtListIndex(2).AtName("uuid")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			list := types.ListValueMust(types.ObjectType{AttrTypes: serverDriveAttrTypes}, test.drives)
+
+			diags := validateServerDrives(ctx, list)
+
+			assert.Equal(t, test.expected, diagnosticPaths(diags))
+		})
+	}
+}
+
+func TestServerResource_serverDeleteRecurse(t *testing.T) {
+	tests := map[string]string{
+		"none":  server.RecurseNone,
+		"disks": server.RecurseDisks,
+		"all":   server.RecurseAllDrives,
+		"":      server.RecurseNone,
+	}
+
+	for deleteDrives, expected := range tests {
+		t.Run(deleteDrives, func(t *testing.T) {
+			assert.Equal(t, expected, serverDeleteRecurse(deleteDrives))
+		})
+	}
+}
+
+func TestServerResource_updateWithoutTags(t *testing.T) {
+	var body string
+	client := newTestClient(&body, "{}")
+
+	// removing the last tag must send an empty list instead of omitting it
+	var data serverResourceModel
+	updateRequest := expandServerDefinition(&cloudsigma.Server{Name: "web"}, data)
+	updateRequest.Tags = mergeTags(nil, nil, expandDefaultTags(nil))
+	_, err := server.Update(context.Background(), client, "server-uuid", updateRequest)
+	assert.NoError(t, err)
+
+	var payload map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(body), &payload))
+	assert.Equal(t, []any{}, payload["tags"])
+	assert.Equal(t, "web", payload["name"])
+}
+
+func TestServerResource_updateWithoutDrives(t *testing.T) {
+	ctx := context.Background()
+	var body string
+	client := newTestClient(&body, "{}")
+
+	// removing the last drive must send an empty list instead of omitting it
+	data := serverResourceModel{
+		BootDisk: types.ObjectNull(serverBootDiskAttrTypes),
+		Drives:   types.ListValueMust(types.ObjectType{AttrTypes: serverDriveAttrTypes}, []attr.Value{}),
+	}
+	updateRequest := expandServerDefinition(&cloudsigma.Server{Name: "web"}, data)
+	var diags diag.Diagnostics
+	updateRequest.Drives, diags = expandServerDrives(ctx, data)
+	assert.False(t, diags.HasError(), diags)
+	_, err := server.Update(ctx, client, "server-uuid", updateRequest)
+	assert.NoError(t, err)
+
+	assert.Contains(t, body, `"drives":[]`)
+}
+
+func TestServerResource_mergeTags(t *testing.T) {
+	tags := []cloudsigma.Tag{{UUID: "tag-1"}, {UUID: "tag-2"}}
+	tagsByName := []cloudsigma.Tag{{UUID: "tag-2"}, {UUID: "tag-3"}}
+
+	assert.Equal(t, []cloudsigma.Tag{{UUID: "tag-1"}, {UUID: "tag-2"}, {UUID: "tag-3"}}, mergeTags(tags, tagsByName))
+	assert.Equal(t, []cloudsigma.Tag{}, mergeTags(nil, nil))
+}
+
+func TestServerResource_deleteServerTagsAfterError(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	httpClient := &http.Client{
+		Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+			requests = append(requests, request.Method+" "+request.URL.Path)
+			response := "{}"
+			switch request.Method {
+			case http.MethodGet:
+				response = `{"objects":[{"uuid":"uuid-prod","name":"prod"}]}`
+			case http.MethodPost:
+				response = `{"objects":[{"uuid":"uuid-web","name":"web"}]}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(response)),
+				Request:    request,
+			}, nil
+		}),
+	}
+	client := cloudsigma.NewClient(cloudsigma.NewTokenCredentialsProvider("token"), cloudsigma.WithHTTPClient(httpClient))
+
+	// only the tag created for the server is deleted, not the existing one
+	tagNames := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("prod"), types.StringValue("web")})
+	tags, created, diags := expandTagNames(ctx, client, tagNames)
+	assert.False(t, diags.HasError(), diags)
+	assert.ElementsMatch(t, []cloudsigma.Tag{{UUID: "uuid-prod"}, {UUID: "uuid-web"}}, tags)
+	assert.Equal(t, []string{"uuid-web"}, created)
+
+	requests = nil
+	deleteServerTagsAfterError(ctx, client, created)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, http.MethodDelete, strings.Fields(requests[0])[0])
+	assert.True(t, strings.HasSuffix(requests[0], "/tags/uuid-web/"), requests[0])
+}
+
+func TestServerResource_flattenTagNames(t *testing.T) {
+	ctx := context.Background()
+	tags := []cloudsigma.Tag{{UUID: "uuid-db"}, {UUID: "uuid-prod"}, {UUID: "uuid-web"}}
+	names := map[string]string{
+		"uuid-db":   "db",
+		"uuid-prod": "prod",
+		"uuid-web":  "web",
+	}
+
+	type testCase struct {
+		priorTags        types.Set
+		priorTagNames    types.Set
+		defaultTags      []string
+		expectedTags     []string
+		expectedTagNames []string
+	}
+	tests := map[string]testCase{
+		"by_name": {
+			priorTags:        types.SetUnknown(types.StringType),
+			priorTagNames:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("db"), types.StringValue("web")}),
+			expectedTags:     []string{"uuid-prod"},
+			expectedTagNames: []string{"db", "web"},
+		},
+		"by_name_and_uuid": {
+			priorTags:        types.SetValueMust(types.StringType, []attr.Value{types.StringValue("uuid-web")}),
+			priorTagNames:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web")}),
+			expectedTags:     []string{"uuid-db", "uuid-prod", "uuid-web"},
+			expectedTagNames: []string{"web"},
+		},
+		"removed_name": {
+			priorTags:        types.SetValueMust(types.StringType, []attr.Value{}),
+			priorTagNames:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("cache"), types.StringValue("web")}),
+			expectedTags:     []string{"uuid-db", "uuid-prod"},
+			expectedTagNames: []string{"web"},
+		},
+		"default_tags": {
+			priorTags:        types.SetUnknown(types.StringType),
+			priorTagNames:    types.SetNull(types.StringType),
+			defaultTags:      []string{"uuid-prod"},
+			expectedTags:     []string{"uuid-db", "uuid-web"},
+			expectedTagNames: nil,
+		},
+		"default_tags_and_uuid": {
+			priorTags:        types.SetValueMust(types.StringType, []attr.Value{types.StringValue("uuid-prod")}),
+			priorTagNames:    types.SetNull(types.StringType),
+			defaultTags:      []string{"uuid-prod"},
+			expectedTags:     []string{"uuid-db", "uuid-prod", "uuid-web"},
+			expectedTagNames: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			flattenedTags, flattenedTagNames, diags := flattenTagNames(ctx, tags, names, test.priorTags, test.priorTagNames, test.defaultTags)
+			assert.False(t, diags.HasError(), diags)
+
+			var uuids, tagNames []string
+			assert.False(t, flattenedTags.ElementsAs(ctx, &uuids, false).HasError())
+			assert.False(t, flattenedTagNames.ElementsAs(ctx, &tagNames, false).HasError())
+			assert.ElementsMatch(t, test.expectedTags, uuids)
+			assert.ElementsMatch(t, test.expectedTagNames, tagNames)
+		})
+	}
+}
+
+func TestServerResource_planTagsAll(t *testing.T) {
+	ctx := context.Background()
+
+	type testCase struct {
+		defaultTags []string
+		tags        types.Set
+		tagNames    types.Set
+		expected    types.Set
+	}
+	tests := map[string]testCase{
+		"unknown_tags": {
+			defaultTags: []string{"uuid-prod"},
+			tags:        types.SetUnknown(types.StringType),
+			tagNames:    types.SetNull(types.StringType),
+			expected:    types.SetUnknown(types.StringType),
+		},
+		"unresolved_tag_names": {
+			defaultTags: []string{"uuid-prod"},
+			tags:        types.SetNull(types.StringType),
+			tagNames:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web")}),
+			expected:    types.SetUnknown(types.StringType),
+		},
+		"default_tags": {
+			defaultTags: []string{"uuid-prod"},
+			tags:        types.SetValueMust(types.StringType, []attr.Value{types.StringValue("uuid-db"), types.StringValue("uuid-prod")}),
+			tagNames:    types.SetNull(types.StringType),
+			expected:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("uuid-db"), types.StringValue("uuid-prod")}),
+		},
+		"without_tags": {
+			defaultTags: nil,
+			tags:        types.SetNull(types.StringType),
+			tagNames:    types.SetNull(types.StringType),
+			expected:    types.SetValueMust(types.StringType, []attr.Value{}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, diags := planTagsAll(ctx, nil, test.defaultTags, test.tags, test.tagNames)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestServerResource_modifyPlanTagsAll(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResponse resource.SchemaResponse
+	NewSnapshotResource().Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+
+	plan := tfsdk.Plan{Schema: schemaResponse.Schema}
+	diags := plan.Set(ctx, &snapshotResourceModel{
+		Tags:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("uuid-db")}),
+		TagsAll: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("uuid-db")}),
+	})
+	assert.False(t, diags.HasError(), diags)
+
+	// the default tags of the provider changed
+	response := resource.ModifyPlanResponse{Plan: plan}
+	changed := modifyPlanTagsAll(ctx, nil, []string{"uuid-prod"}, resource.ModifyPlanRequest{Plan: plan}, &response)
+	assert.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+	assert.True(t, changed)
+
+	var tagsAll types.Set
+	response.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)
+	assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("uuid-db"),
+		types.StringValue("uuid-prod"),
+	}), tagsAll)
+
+	// the default tags of the provider did not change
+	changed = modifyPlanTagsAll(ctx, nil, nil, resource.ModifyPlanRequest{Plan: plan}, &response)
+	assert.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+	assert.False(t, changed)
+
+	// nothing to do on destroy
+	destroy := tfsdk.Plan{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil)}
+	response = resource.ModifyPlanResponse{Plan: destroy}
+	changed = modifyPlanTagsAll(ctx, nil, []string{"uuid-prod"}, resource.ModifyPlanRequest{Plan: destroy}, &response)
+	assert.False(t, response.Diagnostics.HasError(), response.Diagnostics)
+	assert.False(t, changed)
+}
+
+func TestServerResource_findIPv4Address(t *testing.T) {
+	type testCase struct {
+		server      *cloudsigma.Server
+		addressType string
+		expected    string
+	}
+	tests := map[string]testCase{
+		"without_runtime": {
+			server:      &cloudsigma.Server{},
+			addressType: "public",
+			expected:    "",
+		},
+		"private_only": {
+			server: &cloudsigma.Server{
+				Runtime: &cloudsigma.ServerRuntime{
+					RuntimeNICs: []cloudsigma.ServerRuntimeNIC{{
+						InterfaceType: "private", IPv4: cloudsigma.ServerRuntimeIP{UUID: "10.1.1.1"},
+					}},
+				},
+			},
+			addressType: "public",
+			expected:    "",
+		},
+		"public": {
+			server: &cloudsigma.Server{
+				Runtime: &cloudsigma.ServerRuntime{
+					RuntimeNICs: []cloudsigma.ServerRuntimeNIC{{
+						InterfaceType: "public", IPv4: cloudsigma.ServerRuntimeIP{UUID: "178.33.44.55"},
+					}},
+				},
+			},
+			addressType: "public",
+			expected:    "178.33.44.55",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := findIPv4Address(test.server, test.addressType)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+// diagnosticPaths returns the attribute paths of the diagnostics.
+func diagnosticPaths(diags diag.Diagnostics) []path.Path {
+	var paths []path.Path
+	for _, d := range diags {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path())
+		}
+	}
+	return paths
+}
+
+func listOfInt64(values ...int64) types.List {
+	elements, _ := types.ListValueFrom(context.Background(), types.Int64Type, values)
+	return elements
+}
+
+// testAccCloudSigmaServerImportStep imports the server, ignoring the IO
+// statistics of the running server which change between reads.
+var testAccCloudSigmaServerImportStep = acc.TestStep{
+	ResourceName:            "cloudsigma_server.test",
+	ImportState:             true,
+	ImportStateVerify:       true,
+	ImportStateVerifyIgnore: []string{"runtime_nics"},
+}
+
+func testAccCheckServerDestroy(s *terraform.State) error {
+	ctx := context.Background()
+	client, err := sharedClient("testacc")
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudsigma_server" {
+			continue
+		}
+
+		srv, _, err := client.Servers.Get(ctx, rs.Primary.ID)
+		if err == nil && srv.UUID == rs.Primary.ID {
+			return fmt.Errorf("server (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckServerExists(n string, srv *cloudsigma.Server) acc.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("no server ID set")
+		}
+
+		ctx := context.Background()
+		client, err := sharedClient("testacc")
+		if err != nil {
+			return err
+		}
+
+		retrievedServer, _, err := client.Servers.Get(ctx, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("could not get server: %s", err)
+		}
+
+		if retrievedServer.UUID != rs.Primary.ID {
+			return errors.New("server not found")
+		}
+
+		*srv = *retrievedServer
+		return nil
+	}
+}
+
+func testAccCloudSigmaServerResource(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+}
+`, name)
+}
+
+func testAccCloudSigmaServerResourceWithTag(tagName, serverName string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_tag" "test" {
+  name = "%s"
+}
+
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  tags = [cloudsigma_tag.test.id]
+}
+`, tagName, serverName)
+}
+
+func testAccCloudSigmaServerResourceWithoutTags(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  tags = []
+}
+`, name)
+}
+
+func testAccCloudSigmaServerResourceWithSMP(name string, smp int) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  smp          = %d
+  vnc_password = "VnC!Pa33w0rd"
+}
+`, name, smp)
+}
+
+func testAccCloudSigmaServerResourceWithCPUOptions(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  smp          = 2
+  vnc_password = "VnC!Pa33w0rd"
+
+  cpu_type              = "amd"
+  hypervisor            = "kvm"
+  cpus_instead_of_cores = %[2]t
+  enable_numa           = %[2]t
+  hv_relaxed            = %[2]t
+  hv_tsc                = %[2]t
+}
+`, name, enabled)
+}
+
+func testAccCloudSigmaServerResourceWithPowerState(name, powerState string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  power_state  = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+}
+`, name, powerState)
+}
+
+func testAccCloudSigmaServerResourceWithShutdownMethod(name string, cpu int) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = %d
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  shutdown_method  = "acpi"
+  shutdown_timeout = 60
+}
+`, cpu, name)
+}
+
+func testAccCloudSigmaServerResourceWithBootDisk(name string, sizeGB int) string {
+	return fmt.Sprintf(`
+data "cloudsigma_library_drive" "debian" {
+  filter {
+    name   = "name"
+    values = ["Debian 9.13 Server"]
+  }
+}
+
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  boot_disk = {
+    source_library_drive = data.cloudsigma_library_drive.debian.id
+    size                 = %d * 1024 * 1024 * 1024
+  }
+}
+`, name, sizeGB)
+}
+
+func testAccCloudSigmaServerResourceWithDeleteDrivesOnDestroy(serverName, driveName, deleteDrives string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = 5 * 1024 * 1024 * 1024
+}
+
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  delete_drives_on_destroy = "%s"
+
+  drive = [{
+    uuid = cloudsigma_drive.test.id
+  }]
+}
+`, driveName, serverName, deleteDrives)
+}
+
+func testAccCloudSigmaServerResourceWithDrive(serverName, driveName string, driveSizeGB int) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = %d * 1024 * 1024 * 1024
+}
+
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  drive = [{
+    uuid = cloudsigma_drive.test.id
+  }]
+}
+`, driveName, driveSizeGB, serverName)
+}
+
+func testAccCloudSigmaServerResourceWithMeta(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  meta = {
+    random-key = "random-value"
+  }
+
+  user_data = <<-EOT
+    #cloud-config
+  EOT
+}
+`, name)
+}
+
+func testAccCloudSigmaServerResourceWithChangedMeta(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  meta = {
+    another-key = "another-value"
+    random-key  = "random-value"
+  }
+
+  network_config = <<-EOT
+    version: 2
+  EOT
+
+  user_data = <<-EOT
+    #cloud-config
+  EOT
+}
+`, name)
+}
+
+func testAccCloudSigmaServerResourceWithEmptyMeta(name string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  meta = {}
+}
+`, name)
+}
+
+func testAccCloudSigmaServerResourceWithIgnoreMetaKeys(name, value string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "%s"
+  vnc_password = "VnC!Pa33w0rd"
+
+  ignore_meta_keys = ["agent-key"]
+
+  meta = {
+    random-key = "%s"
+  }
+}
+`, name, value)
+}
+
+func testAccCloudSigmaServerResourceWithEmptySSHKey() string {
+	return `
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "server-with-invalid-empty-ssh-key-element"
+  vnc_password = "VnC!Pa33w0rd"
+
+  ssh_keys = [""]
+}
+`
+}
+
+func testAccCloudSigmaServerResourceWithStaticNetworkWithoutAddress() string {
+	return `
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "server-with-invalid-static-network"
+  vnc_password = "VnC!Pa33w0rd"
+
+  network = [{
+    type = "static"
+  }]
+}
+`
+}
+
+func testAccCloudSigmaServerResourceWithDuplicateDrive() string {
+	return `
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "server-with-invalid-duplicate-drive"
+  vnc_password = "VnC!Pa33w0rd"
+
+  drive = [
+    { uuid = "5b5bd4a9-1f11-4e29-8bbc-a1a5a8e0fd7e" },
+    { uuid = "5b5bd4a9-1f11-4e29-8bbc-a1a5a8e0fd7e" },
+  ]
+}
+`
+}
+
+func testAccCloudSigmaServerResourceWithEmptyTag() string {
+	return `
+resource "cloudsigma_server" "test" {
+  cpu          = 2000
+  memory       = 512 * 1024 * 1024
+  name         = "server-with-invalid-empty-tag-element"
+  vnc_password = "VnC!Pa33w0rd"
+
+  tags = [""]
+}
+`
+}
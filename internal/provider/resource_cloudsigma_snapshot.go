@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -22,11 +26,13 @@ var (
 	_ resource.Resource                = (*snapshotResource)(nil)
 	_ resource.ResourceWithConfigure   = (*snapshotResource)(nil)
 	_ resource.ResourceWithImportState = (*snapshotResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*snapshotResource)(nil)
 )
 
 // snapshotResource is the snapshot resource implementation.
 type snapshotResource struct {
-	client *cloudsigma.Client
+	client      *cloudsigma.Client
+	defaultTags []string
 }
 
 // snapshotResourceModel maps the snapshot resource schema data.
@@ -36,6 +42,8 @@ type snapshotResourceModel struct {
 	ID          types.String `tfsdk:"id"`
 	ResourceURI types.String `tfsdk:"resource_uri"`
 	Status      types.String `tfsdk:"status"`
+	Tags        types.Set    `tfsdk:"tags"`
+	TagsAll     types.Set    `tfsdk:"tags_all"`
 	Timestamp   types.String `tfsdk:"timestamp"`
 	UUID        types.String `tfsdk:"uuid"`
 }
@@ -89,6 +97,24 @@ which makes it possible to restore an older version of a VM image.
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				}},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "A list of the tags UUIDs to be applied to the snapshot. " +
+					"The default tags of the provider are not listed, unless they are also defined here.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags_all": schema.SetAttribute{
+				MarkdownDescription: "The UUIDs of all tags applied to the snapshot, including the default tags of the provider.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 			"timestamp": schema.StringAttribute{
 				MarkdownDescription: "The timestamp of the snapshot creation.",
 				Computed:            true,
@@ -112,7 +138,7 @@ func (r *snapshotResource) Configure(_ context.Context, request resource.Configu
 		return
 	}
 
-	client, ok := request.ProviderData.(*cloudsigma.Client)
+	data, ok := request.ProviderData.(*providerResourceData)
 	if !ok {
 		response.Diagnostics.AddError(
 			"Unconfigured CloudSigma client",
@@ -121,7 +147,8 @@ func (r *snapshotResource) Configure(_ context.Context, request resource.Configu
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.defaultTags = data.defaultTags
 }
 
 func (r *snapshotResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -145,10 +172,17 @@ func (r *snapshotResource) Create(ctx context.Context, request resource.CreateRe
 		return
 	}
 
+	tags, diags := expandTags(ctx, data.Tags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	createRequest := &cloudsigma.SnapshotCreateRequest{
 		Snapshots: []cloudsigma.Snapshot{{
 			Drive: &cloudsigma.Drive{UUID: driveUUID},
 			Name:  data.Name.ValueString(),
+			Tags:  mergeTags(tags, expandDefaultTags(r.defaultTags)),
 		}},
 	}
 	tflog.Trace(ctx, "Creating snapshot", map[string]any{"payload": createRequest})
@@ -184,6 +218,13 @@ func (r *snapshotResource) Create(ctx context.Context, request resource.CreateRe
 	data.Status = types.StringValue(snap.Status)
 	data.Timestamp = types.StringValue(snap.Timestamp)
 	data.UUID = types.StringValue(snap.UUID)
+	data.Tags, _, diags = readTags(ctx, r.client, snap.Tags, data.Tags, types.SetNull(types.StringType), r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	data.TagsAll, diags = flattenTags(ctx, snap.Tags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 
 	diags = response.State.Set(ctx, &data)
 	response.Diagnostics.Append(diags...)
@@ -221,6 +262,13 @@ func (r *snapshotResource) Read(ctx context.Context, request resource.ReadReques
 	data.Status = types.StringValue(snap.Status)
 	data.Timestamp = types.StringValue(snap.Timestamp)
 	data.UUID = types.StringValue(snap.UUID)
+	data.Tags, _, diags = readTags(ctx, r.client, snap.Tags, data.Tags, types.SetNull(types.StringType), r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	data.TagsAll, diags = flattenTags(ctx, snap.Tags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 
 	diags = response.State.Set(ctx, &data)
 	response.Diagnostics.Append(diags...)
@@ -236,11 +284,18 @@ func (r *snapshotResource) Update(ctx context.Context, request resource.UpdateRe
 		return
 	}
 
+	tags, diags := expandTags(ctx, data.Tags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	snapshotUUID := data.ID.ValueString()
 	updateRequest := &cloudsigma.SnapshotUpdateRequest{
 		Snapshot: &cloudsigma.Snapshot{
 			Drive: &cloudsigma.Drive{UUID: data.Drive.ValueString()},
 			Name:  data.Name.ValueString(),
+			Tags:  mergeTags(tags, expandDefaultTags(r.defaultTags)),
 		},
 	}
 	tflog.Trace(ctx, "Updating snapshot", map[string]any{
@@ -262,11 +317,22 @@ func (r *snapshotResource) Update(ctx context.Context, request resource.UpdateRe
 	data.Status = types.StringValue(snap.Status)
 	data.Timestamp = types.StringValue(snap.Timestamp)
 	data.UUID = types.StringValue(snap.UUID)
+	data.Tags, _, diags = readTags(ctx, r.client, snap.Tags, data.Tags, types.SetNull(types.StringType), r.defaultTags)
+	response.Diagnostics.Append(diags...)
+	data.TagsAll, diags = flattenTags(ctx, snap.Tags)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
 
 	diags = response.State.Set(ctx, &data)
 	response.Diagnostics.Append(diags...)
 }
 
+func (r *snapshotResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	modifyPlanTagsAll(ctx, r.client, r.defaultTags, request, response)
+}
+
 func (r *snapshotResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var data snapshotResourceModel
 
@@ -97,7 +97,7 @@ func (r *sshKeyResource) Configure(_ context.Context, request resource.Configure
 	if request.ProviderData == nil {
 		return
 	}
-	r.client = request.ProviderData.(*cloudsigma.Client)
+	r.client = request.ProviderData.(*providerResourceData).client
 }
 
 func (r *sshKeyResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
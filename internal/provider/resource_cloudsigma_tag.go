@@ -78,7 +78,7 @@ func (r *tagResource) Configure(_ context.Context, request resource.ConfigureReq
 	if request.ProviderData == nil {
 		return
 	}
-	r.client = request.ProviderData.(*cloudsigma.Client)
+	r.client = request.ProviderData.(*providerResourceData).client
 }
 
 func (r *tagResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
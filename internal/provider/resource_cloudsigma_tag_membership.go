@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/tag"
+)
+
+var (
+	_ resource.Resource                = (*tagMembershipResource)(nil)
+	_ resource.ResourceWithConfigure   = (*tagMembershipResource)(nil)
+	_ resource.ResourceWithImportState = (*tagMembershipResource)(nil)
+)
+
+// tagMembershipResource is the tag membership resource implementation.
+type tagMembershipResource struct {
+	client *cloudsigma.Client
+}
+
+// tagMembershipResourceModel maps the tag membership resource schema data.
+type tagMembershipResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Resources types.Set    `tfsdk:"resources"`
+	Tag       types.String `tfsdk:"tag"`
+}
+
+func NewTagMembershipResource() resource.Resource {
+	return &tagMembershipResource{}
+}
+
+func (r *tagMembershipResource) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "cloudsigma_tag_membership"
+}
+
+func (r *tagMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: `
+The tag membership resource allows you to manage the resources a CloudSigma tag is applied to.
+
+The membership is authoritative: the tag is removed from all resources which are not listed,
+including resources tagged in the web UI. This makes it possible to tag resources which are not
+managed by Terraform, e.g. purchased IP addresses or VLANs.
+
+~> **Note:** Do not apply the same tag with ` + "`tags`" + `, ` + "`tag_names`" + ` or the default tags of the provider,
+as they would overwrite each other.
+`,
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tag membership, equal to the UUID of the tag.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resources": schema.SetAttribute{
+				MarkdownDescription: "A list of the UUIDs of the servers, drives, IP addresses and VLANs the tag is applied to.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the tag. Changing it forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *tagMembershipResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	data, ok := request.ProviderData.(*providerResourceData)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unconfigured CloudSigma client",
+			"Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *tagMembershipResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data tagMembershipResourceModel
+
+	// read plan data into the model
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	t, diags := r.updateTagResources(ctx, data.Tag.ValueString(), data.Resources)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// map response body to attributes
+	data.ID = types.StringValue(t.UUID)
+	data.Tag = types.StringValue(t.UUID)
+	data.Resources, diags = types.SetValueFrom(ctx, types.StringType, tag.ResourceUUIDs(t))
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *tagMembershipResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data tagMembershipResourceModel
+
+	// read state data into the model
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tagUUID := data.ID.ValueString()
+	tflog.Trace(ctx, "Getting tag", map[string]interface{}{"tag_uuid": tagUUID})
+	t, resp, err := r.client.Tags.Get(ctx, tagUUID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// if the tag is somehow already destroyed, mark as successfully gone
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError("Unable to get tag", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Got tag", map[string]interface{}{"data": t})
+
+	// map response body to attributes
+	data.ID = types.StringValue(t.UUID)
+	data.Tag = types.StringValue(t.UUID)
+	data.Resources, diags = types.SetValueFrom(ctx, types.StringType, tag.ResourceUUIDs(t))
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *tagMembershipResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data tagMembershipResourceModel
+
+	// read plan data into the model
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	t, diags := r.updateTagResources(ctx, data.Tag.ValueString(), data.Resources)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// map response body to attributes
+	data.ID = types.StringValue(t.UUID)
+	data.Resources, diags = types.SetValueFrom(ctx, types.StringType, tag.ResourceUUIDs(t))
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r *tagMembershipResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data tagMembershipResourceModel
+
+	// read state data into the model
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	tagUUID := data.ID.ValueString()
+	tflog.Trace(ctx, "Getting tag", map[string]interface{}{"tag_uuid": tagUUID})
+	t, resp, err := r.client.Tags.Get(ctx, tagUUID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// the tag is removed from all resources together with the tag
+			return
+		}
+		response.Diagnostics.AddError("Unable to get tag", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Removing tag from all resources", map[string]interface{}{"tag_uuid": tagUUID})
+	_, _, err = tag.UpdateResources(ctx, r.client, t, nil)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to update tag resources", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Removed tag from all resources", map[string]interface{}{"tag_uuid": tagUUID})
+}
+
+func (r *tagMembershipResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+}
+
+// updateTagResources replaces the resources of the tag with the given UUIDs.
+func (r *tagMembershipResource) updateTagResources(ctx context.Context, tagUUID string, resources types.Set) (*cloudsigma.Tag, diag.Diagnostics) {
+	var uuids []string
+	diags := resources.ElementsAs(ctx, &uuids, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	tflog.Trace(ctx, "Getting tag", map[string]interface{}{"tag_uuid": tagUUID})
+	t, _, err := r.client.Tags.Get(ctx, tagUUID)
+	if err != nil {
+		diags.AddError("Unable to get tag", err.Error())
+		return nil, diags
+	}
+
+	tflog.Trace(ctx, "Updating tag resources", map[string]interface{}{
+		"resources": uuids,
+		"tag_uuid":  tagUUID},
+	)
+	updated, _, err := tag.UpdateResources(ctx, r.client, t, uuids)
+	if err != nil {
+		diags.AddError("Unable to update tag resources", err.Error())
+		return nil, diags
+	}
+	tflog.Trace(ctx, "Updated tag resources", map[string]interface{}{"data": updated})
+
+	return updated, diags
+}
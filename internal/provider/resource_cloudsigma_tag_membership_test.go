@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/tag"
+)
+
+func TestAccResourceCloudSigmaTagMembership_basic(t *testing.T) {
+	var cloudsigmaTag cloudsigma.Tag
+	tagName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+	driveName := fmt.Sprintf("%s-%s", accTestPrefix, acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckTagDestroy,
+			testAccCheckDriveDestroy,
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudSigmaTagMembershipResource(tagName, driveName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTagExists("cloudsigma_tag.test", &cloudsigmaTag),
+					resource.TestCheckResourceAttrPair("cloudsigma_tag_membership.test", "tag", "cloudsigma_tag.test", "id"),
+					resource.TestCheckResourceAttr("cloudsigma_tag_membership.test", "resources.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("cloudsigma_tag_membership.test", "resources.*", "cloudsigma_drive.test", "id"),
+					func(_ *terraform.State) error {
+						if len(cloudsigmaTag.Resources) != 1 {
+							return fmt.Errorf("expected 1 resource on tag, got %d", len(cloudsigmaTag.Resources))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// remove the tag from the drive outside of Terraform
+				PreConfig: func() {
+					client, err := sharedClient("testacc")
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, _, err := tag.UpdateResources(context.Background(), client, &cloudsigmaTag, nil); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccCloudSigmaTagMembershipResource(tagName, driveName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("cloudsigma_tag_membership.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTagExists("cloudsigma_tag.test", &cloudsigmaTag),
+					resource.TestCheckResourceAttr("cloudsigma_tag_membership.test", "resources.#", "1"),
+					func(_ *terraform.State) error {
+						if len(cloudsigmaTag.Resources) != 1 {
+							return fmt.Errorf("expected 1 resource on tag, got %d", len(cloudsigmaTag.Resources))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "cloudsigma_tag_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCloudSigmaTagMembershipResource(tagName, driveName string) string {
+	return fmt.Sprintf(`
+resource "cloudsigma_tag" "test" {
+  name = "%s"
+}
+
+resource "cloudsigma_drive" "test" {
+  media = "disk"
+  name  = "%s"
+  size  = 5 * 1024 * 1024 * 1024
+}
+
+resource "cloudsigma_tag_membership" "test" {
+  tag       = cloudsigma_tag.test.id
+  resources = [cloudsigma_drive.test.id]
+}
+`, tagName, driveName)
+}
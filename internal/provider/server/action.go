@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// Start starts the server identified by serverUUID, unless it is already
+// running, and waits until it becomes running.
+func Start(ctx context.Context, client *cloudsigma.Client, serverUUID string) error {
+	tflog.Trace(ctx, "Checking server status before starting", map[string]interface{}{"server_uuid": serverUUID})
+	server, _, err := client.Servers.Get(ctx, serverUUID)
+	if err != nil {
+		return fmt.Errorf("unable to get server %s: %w", serverUUID, err)
+	}
+	if server.Status == serverStatusRunning {
+		tflog.Debug(ctx, "Server is already running", map[string]interface{}{"server_uuid": serverUUID})
+		return nil
+	}
+
+	tflog.Debug(ctx, "Starting server", map[string]interface{}{"server_uuid": serverUUID})
+	_, _, err = client.Servers.Start(ctx, serverUUID)
+	if err != nil {
+		return fmt.Errorf("unable to start server %s: %w", serverUUID, err)
+	}
+
+	if err := WaitServerStatusRunning(ctx, client, serverUUID); err != nil {
+		return fmt.Errorf("unable to wait for server %s to become running: %w", serverUUID, err)
+	}
+	return nil
+}
+
+// Stop stops the server identified by serverUUID, unless it is already
+// stopped or does not exist anymore, and waits until it becomes stopped.
+func Stop(ctx context.Context, client *cloudsigma.Client, serverUUID string) error {
+	tflog.Trace(ctx, "Checking server status before stopping", map[string]interface{}{"server_uuid": serverUUID})
+	server, resp, err := client.Servers.Get(ctx, serverUUID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("unable to get server %s: %w", serverUUID, err)
+	}
+	if server.Status == serverStatusStopped {
+		tflog.Debug(ctx, "Server is already stopped", map[string]interface{}{"server_uuid": serverUUID})
+		return nil
+	}
+
+	tflog.Debug(ctx, "Stopping server", map[string]interface{}{"server_uuid": serverUUID})
+	_, _, err = client.Servers.Stop(ctx, serverUUID)
+	if err != nil {
+		return fmt.Errorf("unable to stop server %s: %w", serverUUID, err)
+	}
+
+	if err := WaitServerStatusStopped(ctx, client, serverUUID); err != nil {
+		return fmt.Errorf("unable to wait for server %s to become stopped: %w", serverUUID, err)
+	}
+	return nil
+}
+
+// Shutdown requests an ACPI shutdown of the server identified by serverUUID,
+// unless it is already stopped or does not exist anymore, and waits up to
+// timeout until it becomes stopped. If the guest operating system does not
+// power off in time, the server is stopped the hard way.
+func Shutdown(ctx context.Context, client *cloudsigma.Client, serverUUID string, timeout time.Duration) error {
+	tflog.Trace(ctx, "Checking server status before shutting down", map[string]interface{}{"server_uuid": serverUUID})
+	server, resp, err := client.Servers.Get(ctx, serverUUID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("unable to get server %s: %w", serverUUID, err)
+	}
+	if server.Status == serverStatusStopped {
+		tflog.Debug(ctx, "Server is already stopped", map[string]interface{}{"server_uuid": serverUUID})
+		return nil
+	}
+
+	tflog.Debug(ctx, "Shutting down server", map[string]interface{}{"server_uuid": serverUUID})
+	_, _, err = client.Servers.Shutdown(ctx, serverUUID)
+	if err != nil {
+		return fmt.Errorf("unable to shutdown server %s: %w", serverUUID, err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err = WaitServerStatusStopped(shutdownCtx, client, serverUUID)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("unable to wait for server %s to become stopped: %w", serverUUID, err)
+	}
+
+	tflog.Warn(ctx, "Server did not shut down in time, stopping it", map[string]interface{}{
+		"server_uuid":      serverUUID,
+		"shutdown_timeout": timeout.String(),
+	})
+	return Stop(ctx, client, serverUUID)
+}
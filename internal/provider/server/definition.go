@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// Definition is the server definition sent to the API on create and update.
+// It extends the SDK server with the attributes which the SDK does not
+// support, and always sends the boolean flags, the drives, the meta and the
+// tags, which the SDK omits if they are false or empty.
+type Definition struct {
+	*cloudsigma.Server
+	CPUModel           string `json:"cpu_model,omitempty"`
+	CPUsInsteadOfCores bool   `json:"cpus_instead_of_cores"`
+	EnableNuma         bool   `json:"enable_numa"`
+	HVRelaxed          bool   `json:"hv_relaxed"`
+	HVTSC              bool   `json:"hv_tsc"`
+
+	// Drives shadows the drives of the SDK server, no drives detach all drives.
+	Drives []cloudsigma.ServerDrive `json:"drives"`
+	// Meta shadows the meta of the SDK server, an empty meta removes all keys.
+	Meta map[string]interface{} `json:"meta"`
+	// Tags shadows the tags of the SDK server, no tags remove all tags.
+	Tags []cloudsigma.Tag `json:"tags"`
+}
+
+// Create creates a server from the definition and returns the created server.
+func Create(ctx context.Context, client *cloudsigma.Client, definition Definition) (*cloudsigma.Server, *cloudsigma.Response, error) {
+	createRequest := struct {
+		Servers []Definition `json:"objects"`
+	}{Servers: []Definition{definition}}
+
+	req, err := client.NewRequest(http.MethodPost, "servers/", createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(struct {
+		Servers []cloudsigma.Server `json:"objects"`
+	})
+	resp, err := client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(root.Servers) == 0 {
+		return nil, resp, fmt.Errorf("no server in the create response")
+	}
+
+	return &root.Servers[0], resp, nil
+}
+
+// Update updates the server identified by serverUUID to the definition.
+func Update(ctx context.Context, client *cloudsigma.Client, serverUUID string, definition Definition) (*cloudsigma.Response, error) {
+	req, err := client.NewRequest(http.MethodPut, fmt.Sprintf("servers/%s/", serverUUID), definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Do(ctx, req, nil)
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+const (
+	// RecurseNone deletes only the server.
+	RecurseNone = ""
+	// RecurseDisks deletes the server together with all attached disks.
+	RecurseDisks = "disks"
+	// RecurseAllDrives deletes the server together with all attached drives,
+	// including cdroms.
+	RecurseAllDrives = "all_drives"
+)
+
+// Delete deletes the server identified by serverUUID. The attached drives are
+// deleted together with the server according to recurse. The SDK does not
+// support recursive deletes.
+func Delete(ctx context.Context, client *cloudsigma.Client, serverUUID, recurse string) (*cloudsigma.Response, error) {
+	urlStr := fmt.Sprintf("servers/%s/", serverUUID)
+	if recurse != RecurseNone {
+		urlStr += "?recurse=" + recurse
+	}
+
+	req, err := client.NewRequest(http.MethodDelete, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Do(ctx, req, nil)
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// Details represents the server attributes which are not decoded by the SDK.
+type Details struct {
+	CPUModel  string   `json:"cpu_model"`
+	HVRelaxed bool     `json:"hv_relaxed"`
+	HVTSC     bool     `json:"hv_tsc"`
+	Runtime   *Runtime `json:"runtime"`
+}
+
+// Runtime represents the runtime information of a running server. The SDK
+// omits the MAC address, the VLAN and the IO statistics of the runtime
+// network interface cards, so they are decoded separately.
+type Runtime struct {
+	NICs []RuntimeNIC `json:"nics"`
+}
+
+// RuntimeNIC represents a network interface card of a running server.
+type RuntimeNIC struct {
+	InterfaceType string                   `json:"interface_type"`
+	IO            *RuntimeNICIO            `json:"io"`
+	IPv4          *cloudsigma.ResourceLink `json:"ip_v4"`
+	IPv6          *cloudsigma.ResourceLink `json:"ip_v6"`
+	MAC           string                   `json:"mac"`
+	VLAN          *cloudsigma.ResourceLink `json:"vlan"`
+}
+
+// RuntimeNICIO represents the IO statistics of a runtime network interface
+// card.
+type RuntimeNICIO struct {
+	BytesRecv   int64 `json:"bytes_recv"`
+	BytesSent   int64 `json:"bytes_sent"`
+	PacketsRecv int64 `json:"packets_recv"`
+	PacketsSent int64 `json:"packets_sent"`
+}
+
+// Get returns the server identified by serverUUID together with the details
+// not decoded by the SDK. The runtime is nil if the server is not running.
+func Get(ctx context.Context, client *cloudsigma.Client, serverUUID string) (*cloudsigma.Server, *Details, *cloudsigma.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("servers/%s/", serverUUID), nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var body bytes.Buffer
+	resp, err := client.Do(ctx, req, &body)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	srv := new(cloudsigma.Server)
+	if err := json.Unmarshal(body.Bytes(), srv); err != nil {
+		return nil, nil, resp, err
+	}
+	details := new(Details)
+	if err := json.Unmarshal(body.Bytes(), details); err != nil {
+		return nil, nil, resp, err
+	}
+
+	return srv, details, resp, nil
+}
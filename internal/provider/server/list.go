@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// List returns the detailed definitions of all servers. Unlike the SDK, it
+// disables the pagination of the API so that no server is missed.
+func List(ctx context.Context, client *cloudsigma.Client) ([]cloudsigma.Server, *cloudsigma.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, "servers/detail/?limit=0", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(struct {
+		Servers []cloudsigma.Server `json:"objects"`
+	})
+	resp, err := client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Servers, resp, nil
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+const (
+	serverStatusRunning     = "running"
+	serverStatusStarting    = "starting"
+	serverStatusStopped     = "stopped"
+	serverStatusStopping    = "stopping"
+	serverStatusUnavailable = "unavailable"
+)
+
+func statusServerStatus(ctx context.Context, client *cloudsigma.Client, serverUUID string) retry.StateRefreshFunc {
+	return func() (any, string, error) {
+		server, _, err := client.Servers.Get(ctx, serverUUID)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to get server %s: %w", serverUUID, err)
+		}
+		return server, server.Status, nil
+	}
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider/wait"
+)
+
+func WaitServerStatusRunning(ctx context.Context, client *cloudsigma.Client, serverUUID string) error {
+	stateConf := retry.StateChangeConf{
+		Pending:    []string{serverStatusStopped, serverStatusStarting, serverStatusUnavailable},
+		Target:     []string{serverStatusRunning},
+		Refresh:    statusServerStatus(ctx, client, serverUUID),
+		Timeout:    wait.Timeout(ctx),
+		MinTimeout: 3 * time.Second,
+		Delay:      5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func WaitServerStatusStopped(ctx context.Context, client *cloudsigma.Client, serverUUID string) error {
+	stateConf := retry.StateChangeConf{
+		Pending:    []string{serverStatusRunning, serverStatusStopping, serverStatusUnavailable},
+		Target:     []string{serverStatusStopped},
+		Refresh:    statusServerStatus(ctx, client, serverUUID),
+		Timeout:    wait.Timeout(ctx),
+		MinTimeout: 3 * time.Second,
+		Delay:      5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package tag
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// List returns all tags. Unlike the SDK, it disables the pagination of the
+// API so that no tag is missed.
+func List(ctx context.Context, client *cloudsigma.Client) ([]cloudsigma.Tag, *cloudsigma.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, "tags/?limit=0", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(struct {
+		Tags []cloudsigma.Tag `json:"objects"`
+	})
+	resp, err := client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root.Tags, resp, nil
+}
@@ -0,0 +1,118 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// Names returns the names of all tags by their UUID.
+func Names(ctx context.Context, client *cloudsigma.Client) (map[string]string, error) {
+	tags, _, err := List(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tags: %w", err)
+	}
+
+	names := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		names[tag.UUID] = tag.Name
+	}
+	return names, nil
+}
+
+// Lookup returns the UUIDs of the tags with the given names. Names without a
+// tag are missing from the result. If several tags have the same name, the
+// first one returned by the API is used.
+func Lookup(ctx context.Context, client *cloudsigma.Client, names []string) (map[string]string, error) {
+	tags, _, err := List(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tags: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	uuids := make(map[string]string, len(names))
+	for _, tag := range tags {
+		if _, ok := uuids[tag.Name]; !ok && wanted[tag.Name] {
+			uuids[tag.Name] = tag.UUID
+		}
+	}
+	return uuids, nil
+}
+
+// Ensure returns the UUIDs of the tags with the given names, the missing tags
+// are created. The UUIDs of the created tags are returned separately so that
+// they can be deleted again if the tagged resource cannot be created.
+func Ensure(ctx context.Context, client *cloudsigma.Client, names []string) (map[string]string, []string, error) {
+	uuids, err := Lookup(ctx, client, names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var missing []cloudsigma.Tag
+	for _, name := range names {
+		if _, ok := uuids[name]; !ok {
+			missing = append(missing, cloudsigma.Tag{Name: name})
+		}
+	}
+	if len(missing) == 0 {
+		return uuids, nil, nil
+	}
+
+	createRequest := &cloudsigma.TagCreateRequest{Tags: missing}
+	tflog.Trace(ctx, "Creating tags", map[string]interface{}{"payload": createRequest})
+	createdTags, _, err := client.Tags.Create(ctx, createRequest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create tags: %w", err)
+	}
+	created := make([]string, 0, len(createdTags))
+	for _, tag := range createdTags {
+		uuids[tag.Name] = tag.UUID
+		created = append(created, tag.UUID)
+	}
+	tflog.Trace(ctx, "Created tags", map[string]interface{}{"data": createdTags})
+
+	return uuids, created, nil
+}
+
+// DeleteUnused deletes the tags with the given names which are not applied to
+// any resource.
+func DeleteUnused(ctx context.Context, client *cloudsigma.Client, names []string) error {
+	uuids, err := Lookup(ctx, client, names)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		tagUUID, ok := uuids[name]
+		if !ok {
+			continue
+		}
+
+		tag, resp, err := client.Tags.Get(ctx, tagUUID)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return fmt.Errorf("unable to get tag %s: %w", tagUUID, err)
+		}
+		if len(tag.Resources) > 0 {
+			tflog.Debug(ctx, "Tag is still in use", map[string]interface{}{"tag_uuid": tagUUID})
+			continue
+		}
+
+		tflog.Debug(ctx, "Deleting unused tag", map[string]interface{}{"tag_uuid": tagUUID})
+		resp, err = client.Tags.Delete(ctx, tagUUID)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return fmt.Errorf("unable to delete tag %s: %w", tagUUID, err)
+		}
+	}
+	return nil
+}
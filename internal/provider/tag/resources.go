@@ -0,0 +1,53 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudsigma/cloudsigma-sdk-go/cloudsigma"
+)
+
+// resourcesUpdateRequest represents a request to replace the resources of a
+// tag. Unlike the SDK, it always sends the resources, so that an empty list
+// removes the tag from all resources.
+type resourcesUpdateRequest struct {
+	Meta      map[string]interface{} `json:"meta,omitempty"`
+	Name      string                 `json:"name"`
+	Resources []string               `json:"resources"`
+}
+
+// UpdateResources replaces the resources the tag is applied to with the
+// resources of the given UUIDs. The name and meta of the tag are kept.
+func UpdateResources(ctx context.Context, client *cloudsigma.Client, tag *cloudsigma.Tag, uuids []string) (*cloudsigma.Tag, *cloudsigma.Response, error) {
+	updateRequest := &resourcesUpdateRequest{
+		Meta:      tag.Meta,
+		Name:      tag.Name,
+		Resources: uuids,
+	}
+	if updateRequest.Resources == nil {
+		updateRequest.Resources = []string{}
+	}
+
+	req, err := client.NewRequest(http.MethodPut, fmt.Sprintf("tags/%v/", tag.UUID), updateRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := new(cloudsigma.Tag)
+	resp, err := client.Do(ctx, req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, nil
+}
+
+// ResourceUUIDs returns the UUIDs of the resources the tag is applied to.
+func ResourceUUIDs(tag *cloudsigma.Tag) []string {
+	uuids := make([]string, 0, len(tag.Resources))
+	for _, resource := range tag.Resources {
+		uuids = append(uuids, resource.UUID)
+	}
+	return uuids
+}
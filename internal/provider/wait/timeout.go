@@ -0,0 +1,19 @@
+package wait
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTimeout is used to wait for a resource status if the context has no
+// deadline.
+const DefaultTimeout = 10 * time.Minute
+
+// Timeout returns the time left until the deadline of ctx, so that the waits
+// honor the timeouts configured for the resource operation.
+func Timeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return DefaultTimeout
+}
@@ -5,13 +5,9 @@ import (
 	"flag"
 	"log"
 
-	"github.com/cloudsigma/terraform-provider-cloudsigma/cloudsigma"
-	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
-	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
-	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+
+	"github.com/cloudsigma/terraform-provider-cloudsigma/internal/provider"
 )
 
 var (
@@ -23,31 +19,12 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with debug support")
 	flag.Parse()
 
-	ctx := context.Background()
-
-	upgradedSDKProvider, err := tf5to6server.UpgradeServer(ctx, cloudsigma.Provider().GRPCProvider)
-	if err != nil {
-		log.Fatal(err)
-	}
-	providers := []func() tfprotov6.ProviderServer{
-		providerserver.NewProtocol6(provider.New(version)()),
-		func() tfprotov6.ProviderServer { return upgradedSDKProvider },
-	}
-
-	muxServer, err := tf6muxserver.NewMuxServer(ctx, providers...)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	var serveOpts []tf6server.ServeOpt
-	if debugMode {
-		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	opts := providerserver.ServeOpts{
+		Address: "registry.terraform.io/cloudsigma/cloudsigma",
+		Debug:   debugMode,
 	}
 
-	err = tf6server.Serve(
-		"registry.terraform.io/cloudsigma/cloudsigma",
-		muxServer.ProviderServer, serveOpts...,
-	)
+	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 	if err != nil {
 		log.Fatal(err)
 	}